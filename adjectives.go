@@ -0,0 +1,97 @@
+package gomorphy
+
+import (
+	"slices"
+	"strings"
+)
+
+// Comparative returns the comparative forms of an adjective given in any
+// form, e.g. Comparative("красивая") → ["красивее", "красивей",
+// "покрасивее", "покрасивей"]
+//
+// Plain comparatives come first, followed by the softened "по" variants
+// (Cmp2 in the dictionary). Returns nil if the word is not an adjective or
+// has no comparative
+func (a *Analyzer) Comparative(adj string) []string {
+	forms, _ := a.adjectiveForms(adj)
+	var plain, po []string
+	for _, f := range forms {
+		if tagPOS(f.tag) != "COMP" {
+			continue
+		}
+		if strings.Contains(f.tag, "Cmp2") {
+			po = appendUnique(po, f.text)
+		} else {
+			plain = appendUnique(plain, f.text)
+		}
+	}
+	return append(plain, po...)
+}
+
+// Superlative returns the synthetic superlatives of an adjective given in
+// any form in the masculine nominative singular, e.g.
+// Superlative("красивый") → ["красивейший", "наикрасивейший"]
+//
+// Like any full adjective, the forms decline in phrases
+// Returns nil if the word is not an adjective or has no superlative
+func (a *Analyzer) Superlative(adj string) []string {
+	forms, _ := a.adjectiveForms(adj)
+	var sup []string
+	for _, f := range forms {
+		if tagPOS(f.tag) == "ADJF" && strings.Contains(f.tag, "Supr") && tagMatches(f.tag, "nomn", "sing", "masc", "") {
+			sup = appendUnique(sup, f.text)
+		}
+	}
+	return sup
+}
+
+// ShortForms returns the short forms of an adjective given in any form,
+// keyed by gender in the singular and by "plur" in the plural, e.g.
+// ShortForms("красивый") → {"masc": "красив", "femn": "красива",
+// "neut": "красиво", "plur": "красивы"}
+// Returns nil if the word is not an adjective or has no short forms
+func (a *Analyzer) ShortForms(adj string) map[string]string {
+	forms, _ := a.adjectiveForms(adj)
+	var short map[string]string
+	for _, f := range forms {
+		if tagPOS(f.tag) != "ADJS" {
+			continue
+		}
+		key := tagGrammeme(f.tag, numberGrammemes)
+		if g := tagGrammeme(f.tag, genderGrammemes); key == "sing" && g != "" {
+			key = g
+		}
+		if short == nil {
+			short = make(map[string]string)
+		}
+		if _, ok := short[key]; !ok {
+			short[key] = f.text
+		}
+	}
+	return short
+}
+
+// adjectiveForms returns every form of the adjective lexeme of adj,
+// taking the first adjectival parse
+func (a *Analyzer) adjectiveForms(adj string) ([]lexemeForm, bool) {
+	adj = strings.ToLower(strings.TrimSpace(adj))
+	for _, p := range a.parses(adj) {
+		switch tagPOS(p.tag) {
+		case "ADJF", "ADJS", "COMP":
+		default:
+			continue
+		}
+		if forms, ok := a.lexemeForms(adj, p.entry); ok {
+			return forms, true
+		}
+	}
+	return nil, false
+}
+
+// appendUnique appends s to list unless it is already there
+func appendUnique(list []string, s string) []string {
+	if slices.Contains(list, s) {
+		return list
+	}
+	return append(list, s)
+}
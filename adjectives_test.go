@@ -0,0 +1,54 @@
+package gomorphy
+
+import (
+	"maps"
+	"slices"
+	"testing"
+)
+
+func TestComparative(t *testing.T) {
+	a := testAnalyzer
+
+	tests := []struct {
+		adj  string
+		want []string
+	}{
+		{"красивый", []string{"красивее", "красивей", "покрасивее", "покрасивей"}},
+		{"красивая", []string{"красивее", "красивей", "покрасивее", "покрасивей"}},
+		{"красивее", []string{"красивее", "красивей", "покрасивее", "покрасивей"}},
+	}
+
+	for _, tt := range tests {
+		if got := a.Comparative(tt.adj); !slices.Equal(got, tt.want) {
+			t.Errorf("Comparative(%q) = %v, want %v", tt.adj, got, tt.want)
+		}
+	}
+
+	if got := a.Comparative("стол"); got != nil {
+		t.Errorf("Comparative(\"стол\") = %v, want nil", got)
+	}
+}
+
+func TestSuperlative(t *testing.T) {
+	a := testAnalyzer
+
+	want := []string{"красивейший", "наикрасивейший"}
+	if got := a.Superlative("красивого"); !slices.Equal(got, want) {
+		t.Errorf("Superlative(\"красивого\") = %v, want %v", got, want)
+	}
+	if got := a.Superlative("деревянный"); got != nil {
+		t.Errorf("Superlative(\"деревянный\") = %v, want nil", got)
+	}
+}
+
+func TestShortForms(t *testing.T) {
+	a := testAnalyzer
+
+	want := map[string]string{"masc": "красив", "femn": "красива", "neut": "красиво", "plur": "красивы"}
+	if got := a.ShortForms("красивые"); !maps.Equal(got, want) {
+		t.Errorf("ShortForms(\"красивые\") = %v, want %v", got, want)
+	}
+	if got := a.ShortForms("кошка"); got != nil {
+		t.Errorf("ShortForms(\"кошка\") = %v, want nil", got)
+	}
+}
@@ -102,6 +102,13 @@ func (a *Analyzer) Tag(word string) string {
 	return a.bestTag(entries)
 }
 
+// parse is a single dictionary analysis of a word: the lexeme entry together
+// with the tag of the analysed form
+type parse struct {
+	entry wordEntry
+	tag   string
+}
+
 // posPriority defines disambiguation preference: lower = preferred.
 var posPriority = map[string]int{
 	"NOUN": 1, "NPRO": 1,
@@ -112,37 +119,44 @@ var posPriority = map[string]int{
 
 // bestTag picks the tag from entries with the highest-priority POS.
 func (a *Analyzer) bestTag(entries []wordEntry) string {
-	best := ""
+	p, _ := a.bestParse(entries)
+	return p.tag
+}
+
+// bestParse picks the parse from entries with the highest-priority POS
+// Reports false if none of the entries carries a valid tag
+func (a *Analyzer) bestParse(entries []wordEntry) (parse, bool) {
+	var best parse
+	found := false
 	bestPri := 99
 	for _, e := range entries {
-		para := a.paradigms[e.paradigmID]
-		n := len(para) / 3
-		tagID := para[n+int(e.formIdx)]
-		if int(tagID) >= len(a.gramtab) {
+		t := a.entryTag(e)
+		if t == "" {
 			continue
 		}
-		t := a.gramtab[tagID]
 		pri, ok := posPriority[tagPOS(t)]
 		if !ok {
 			pri = 10
 		}
-		if best == "" || pri < bestPri {
-			best = t
+		if !found || pri < bestPri {
+			best = parse{entry: e, tag: t}
 			bestPri = pri
+			found = true
 		}
 	}
-	return best
+	return best, found
 }
 
 // PhraseFormsConcordant generates all grammatical forms of a Russian phrase
 // while keeping adjective–noun agreement intact
 //
-// The rightmost noun (or pronoun) is treated as the grammatical head
-// For every case × number combination the head is declined, and any
-// adjectives/participles are agreed in case, number, gender, and animacy
-// Prepositions, conjunctions, and words not found in the dictionary are
-// left unchanged. The original phrase is always the first element of the
-// returned slice
+// The first noun (or pronoun) is treated as the grammatical head and declined
+// into each of the six main cases in both numbers; its adjectives and
+// participles agree with it, while genitive dependents, prepositional groups,
+// conjunctions and unknown words keep their form. Combinations the phrase
+// cannot be declined into are skipped; see [Analyzer.PhraseFormsTable] for
+// the rules and for the forms keyed by case and number
+// The original phrase is always the first element of the returned slice
 func (a *Analyzer) PhraseFormsConcordant(phrase string) []string {
 	phrase = strings.ToLower(strings.TrimSpace(phrase))
 	words := strings.Fields(phrase)
@@ -157,37 +171,12 @@ func (a *Analyzer) PhraseFormsConcordant(phrase string) []string {
 		return []string{words[0]}
 	}
 
-	type wordInfo struct {
-		pos     string
-		animacy string
-		gender  string
-	}
-	infos := make([]wordInfo, len(words))
-	headIdx := -1
-
-	for i, w := range words {
-		if serviceWords[w] {
-			continue
-		}
-		tag := a.Tag(w)
-		if tag == "" {
-			continue
-		}
-		pos := tagPOS(tag)
-		infos[i] = wordInfo{
-			pos:     pos,
-			animacy: tagGrammeme(tag, []string{"anim", "inan"}),
-			gender:  tagGrammeme(tag, []string{"masc", "femn", "neut"}),
-		}
-		if pos == "NOUN" || pos == "NPRO" {
-			headIdx = i
-		}
-	}
+	p := a.analyzePhrase(words)
 
 	seen := map[string]struct{}{phrase: {}}
 	result := []string{phrase}
 
-	if headIdx == -1 {
+	if p.head == -1 {
 		// No noun found -- flatten individual word forms
 		for _, w := range words {
 			if serviceWords[w] {
@@ -203,28 +192,15 @@ func (a *Analyzer) PhraseFormsConcordant(phrase string) []string {
 		return result
 	}
 
-	head := infos[headIdx]
-	cases := []string{"nomn", "gent", "datv", "accs", "ablt", "loct"}
-	numbers := []string{"sing", "plur"}
-
-	for _, number := range numbers {
-		for _, cas := range cases {
-			declined := make([]string, len(words))
-			for i, w := range words {
-				if serviceWords[w] {
-					declined[i] = w
-					continue
-				}
-				switch infos[i].pos {
-				case "NOUN", "NPRO":
-					declined[i] = a.inflect(w, cas, number, "", "")
-				case "ADJF", "PRTF":
-					declined[i] = a.inflectAdj(w, cas, number, head.gender, head.animacy)
-				default:
-					declined[i] = w
-				}
+	for _, number := range phraseNumbers {
+		for _, cas := range phraseCases {
+			if !p.allows(cas) || !p.allowsNumber(number) {
+				continue
+			}
+			form, ok := a.declinePhrase(p, cas, number)
+			if !ok {
+				continue
 			}
-			form := strings.Join(declined, " ")
 			if _, ok := seen[form]; !ok {
 				seen[form] = struct{}{}
 				result = append(result, form)
@@ -252,6 +228,9 @@ var serviceWords = map[string]bool{
 	"между": true, "среди": true, "около": true, "после": true, "перед": true,
 	"вокруг": true, "против": true, "вместо": true, "кроме": true,
 	"с": true, "со": true, "к": true, "ко": true, "о": true,
+	"у": true, "изо": true, "ото": true, "подо": true, "передо": true,
+	"из-за": true, "из-под": true, "сквозь": true, "ради": true, "мимо": true,
+	"возле": true, "благодаря": true,
 	"и": true, "или": true, "но": true, "а": true, "не": true, "ни": true,
 	"как": true, "что": true, "это": true,
 }
@@ -336,21 +315,73 @@ func (a *Analyzer) inflect(word, cas, number, gender, animacy string) string {
 	}
 
 	for _, e := range sorted {
-		para := a.paradigms[e.paradigmID]
-		n := len(para) / 3
-		stem, ok := a.extractStem(word, para, n, int(e.formIdx))
-		if !ok {
-			continue
-		}
-		for i := 0; i < n; i++ {
-			if tagMatches(a.gramtab[para[n+i]], cas, number, gender, animacy) {
-				return paradigmPrefixes[para[2*n+i]] + stem + a.suffixes[para[i]]
-			}
+		if f, ok := a.inflectEntry(word, e, cas, number, gender, animacy); ok {
+			return f
 		}
 	}
 	return word
 }
 
+// inflectEntry declines word within the single lexeme described by e
+// Reports false if word does not fit the paradigm or no form matches
+func (a *Analyzer) inflectEntry(word string, e wordEntry, cas, number, gender, animacy string) (string, bool) {
+	para := a.paradigms[e.paradigmID]
+	n := len(para) / 3
+	stem, ok := a.extractStem(word, para, n, int(e.formIdx))
+	if !ok {
+		return "", false
+	}
+	for i := 0; i < n; i++ {
+		if tagMatches(a.gramtab[para[n+i]], cas, number, gender, animacy) {
+			return paradigmPrefixes[para[2*n+i]] + stem + a.suffixes[para[i]], true
+		}
+	}
+	return "", false
+}
+
+// lexemeForm is one form of a lexeme together with its tag
+type lexemeForm struct {
+	text, tag string
+}
+
+// lexemeForms returns every form of the lexeme described by e in paradigm
+// order, word being the form e was looked up with
+// Reports false if word does not fit the paradigm
+func (a *Analyzer) lexemeForms(word string, e wordEntry) ([]lexemeForm, bool) {
+	para := a.paradigms[e.paradigmID]
+	n := len(para) / 3
+	if int(e.formIdx) >= n {
+		return nil, false
+	}
+	stem, ok := a.extractStem(word, para, n, int(e.formIdx))
+	if !ok {
+		return nil, false
+	}
+	forms := make([]lexemeForm, 0, n)
+	for i := 0; i < n; i++ {
+		if int(para[n+i]) >= len(a.gramtab) {
+			continue
+		}
+		forms = append(forms, lexemeForm{
+			text: paradigmPrefixes[para[2*n+i]] + stem + a.suffixes[para[i]],
+			tag:  a.gramtab[para[n+i]],
+		})
+	}
+	return forms, true
+}
+
+// entryTag returns the tag of the form described by e,
+// or an empty string if the tag ID is out of range
+func (a *Analyzer) entryTag(e wordEntry) string {
+	para := a.paradigms[e.paradigmID]
+	n := len(para) / 3
+	tagID := para[n+int(e.formIdx)]
+	if int(tagID) >= len(a.gramtab) {
+		return ""
+	}
+	return a.gramtab[tagID]
+}
+
 func (a *Analyzer) entryPriority(e wordEntry) int {
 	para := a.paradigms[e.paradigmID]
 	n := len(para) / 3
@@ -368,6 +399,13 @@ func (a *Analyzer) entryPriority(e wordEntry) int {
 // inflectAdj inflects an adjective, applying the Russian accusative rule:
 // inanimate accusative is identical to nominative; animate is identical to genitive
 func (a *Analyzer) inflectAdj(word, cas, number, gender, animacy string) string {
+	cas, gender = adjAgreement(cas, number, gender, animacy)
+	return a.inflect(word, cas, number, gender, "")
+}
+
+// adjAgreement maps the case and gender of a head noun to the case and gender
+// an agreeing adjective must be looked up with in its paradigm
+func adjAgreement(cas, number, gender, animacy string) (string, string) {
 	effectiveCas := cas
 	if cas == "accs" {
 		switch {
@@ -393,7 +431,7 @@ func (a *Analyzer) inflectAdj(word, cas, number, gender, animacy string) string
 	if number == "plur" {
 		g = ""
 	}
-	return a.inflect(word, effectiveCas, number, g, "")
+	return effectiveCas, g
 }
 
 // extractStem strips the paradigm prefix and suffix of form formIdx from word,
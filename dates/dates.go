@@ -0,0 +1,186 @@
+// Package dates formats dates in Russian, declining month and weekday names
+// through the gomorphy analyzer, which [time.Time.Format] cannot do:
+//
+//	f := dates.New(a)
+//	f.Date(t, "nomn")               // "16 октября 2026 года"
+//	f.WeekdayWith("к", t.Weekday()) // "к пятнице"
+//	f.Range(from, to)               // "с 3 по 10 марта 2026 года"
+package dates
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jus1d/gomorphy"
+)
+
+// monthNames are the dictionary forms of the months, January first
+var monthNames = [...]string{
+	"январь", "февраль", "март", "апрель", "май", "июнь",
+	"июль", "август", "сентябрь", "октябрь", "ноябрь", "декабрь",
+}
+
+// weekdayNames are the dictionary forms of the weekdays indexed by
+// [time.Weekday], so Sunday first
+var weekdayNames = [...]string{
+	"воскресенье", "понедельник", "вторник", "среда", "четверг", "пятница", "суббота",
+}
+
+// Formatter formats dates with the analyzer it was created with
+// The zero options write days and years in digits and always include the year
+type Formatter struct {
+	a *gomorphy.Analyzer
+
+	// SpellDay writes the day of the month as an ordinal: "шестнадцатое октября"
+	SpellDay bool
+	// SpellYear writes the year as an ordinal: "две тысячи двадцать шестого года"
+	SpellYear bool
+	// OmitYear leaves the year out of dates and ranges: "16 октября"
+	OmitYear bool
+}
+
+// New returns a Formatter using the analyzer a
+func New(a *gomorphy.Analyzer) *Formatter {
+	return &Formatter{a: a}
+}
+
+// Month returns the name of m declined to cas,
+// e.g. Month(time.March, "gent") → "марта"
+// Reports false for an invalid month or case
+func (f *Formatter) Month(m time.Month, cas string) (string, bool) {
+	if m < time.January || m > time.December {
+		return "", false
+	}
+	return f.a.InflectPhrase(monthNames[m-1], cas, "sing")
+}
+
+// Weekday returns the name of d declined to cas,
+// e.g. Weekday(time.Friday, "datv") → "пятнице"
+// Reports false for an invalid weekday or case
+func (f *Formatter) Weekday(d time.Weekday, cas string) (string, bool) {
+	if d < time.Sunday || d > time.Saturday {
+		return "", false
+	}
+	return f.a.InflectPhrase(weekdayNames[d], cas, "sing")
+}
+
+// weekdayCases overrides the case a preposition takes before a weekday
+// where it is not the first case the preposition governs
+var weekdayCases = map[string]string{
+	"о": "loct", "об": "loct", "обо": "loct", // "о понедельнике"
+	"по": "accs", // "с понедельника по пятницу"
+}
+
+// WeekdayWith returns d after the preposition prep: "в понедельник",
+// "к пятнице", "до среды", "о понедельнике", "по пятницу"
+//
+// The weekday takes the first case the preposition governs, except after
+// "о" (locative) and "по" (accusative, as in a range of days)
+// Reports false for an unknown preposition or an invalid weekday
+func (f *Formatter) WeekdayWith(prep string, d time.Weekday) (string, bool) {
+	cases := gomorphy.PrepositionCases(prep)
+	if len(cases) == 0 || d < time.Sunday || d > time.Saturday {
+		return "", false
+	}
+	cas, ok := weekdayCases[strings.ToLower(prep)]
+	if !ok {
+		cas = cases[0]
+	}
+	return f.a.InflectPhrase(prep+" "+weekdayNames[d], cas, "sing")
+}
+
+// Date formats the date of t, e.g. "16 октября 2026 года"
+//
+// The month and year are always in the genitive; cas declines the day when
+// it is spelled, so Date(t, "gent") after "до" gives "до шестнадцатого
+// октября" and Date(t, "datv") after "к" gives "к шестнадцатому октября"
+// Reports false for an unsupported case
+func (f *Formatter) Date(t time.Time, cas string) (string, bool) {
+	day, ok := f.day(t.Day(), cas)
+	if !ok {
+		return "", false
+	}
+	rest, ok := f.monthYear(t)
+	if !ok {
+		return "", false
+	}
+	return day + " " + rest, true
+}
+
+// Range formats the period from one date to another inclusive as
+// "с … по …", sharing the month and year where they coincide:
+// "с 3 по 10 марта 2026 года", "с 28 февраля по 3 марта 2026 года",
+// "с 28 декабря 2025 года по 3 января 2026 года"
+// Reports false if a month name cannot be declined
+func (f *Formatter) Range(from, to time.Time) (string, bool) {
+	fromDay, ok := f.day(from.Day(), "gent")
+	if !ok {
+		return "", false
+	}
+	toDay, ok := f.day(to.Day(), "accs")
+	if !ok {
+		return "", false
+	}
+	toRest, ok := f.monthYear(to)
+	if !ok {
+		return "", false
+	}
+
+	var b strings.Builder
+	b.WriteString(f.a.PrepositionForm("с", fromDay) + " " + fromDay)
+	switch {
+	case from.Year() == to.Year() && from.Month() == to.Month():
+	case from.Year() == to.Year():
+		m, ok := f.Month(from.Month(), "gent")
+		if !ok {
+			return "", false
+		}
+		b.WriteString(" " + m)
+	default:
+		fromRest, ok := f.monthYear(from)
+		if !ok {
+			return "", false
+		}
+		b.WriteString(" " + fromRest)
+	}
+	b.WriteString(" по " + toDay + " " + toRest)
+	return b.String(), true
+}
+
+// day writes the day of the month in digits, or as a neuter ordinal in cas
+// agreeing with the implied "число"
+func (f *Formatter) day(d int, cas string) (string, bool) {
+	if f.SpellDay {
+		return f.a.SpellOrdinal(int64(d), cas, "sing", "neut", "")
+	}
+	if !validCase(cas) {
+		return "", false
+	}
+	return strconv.Itoa(d), true
+}
+
+// monthYear writes the genitive month followed by the year unless omitted:
+// "октября 2026 года"
+func (f *Formatter) monthYear(t time.Time) (string, bool) {
+	m, ok := f.Month(t.Month(), "gent")
+	if !ok || f.OmitYear {
+		return m, ok
+	}
+	year := strconv.Itoa(t.Year())
+	if f.SpellYear {
+		if year, ok = f.a.SpellOrdinal(int64(t.Year()), "gent", "sing", "masc", ""); !ok {
+			return "", false
+		}
+	}
+	return m + " " + year + " года", true
+}
+
+// validCase reports whether cas is one of the six main cases
+func validCase(cas string) bool {
+	switch cas {
+	case "nomn", "gent", "datv", "accs", "ablt", "loct":
+		return true
+	}
+	return false
+}
@@ -0,0 +1,125 @@
+package dates
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jus1d/gomorphy"
+)
+
+// shared formatter reused across all tests
+var testFormatter = func() *Formatter {
+	a, err := gomorphy.Default()
+	if err != nil {
+		panic("failed to load analyzer: " + err.Error())
+	}
+	return New(a)
+}()
+
+func date(y int, m time.Month, d int) time.Time {
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+func TestMonth(t *testing.T) {
+	f := testFormatter
+
+	tests := []struct {
+		m    time.Month
+		cas  string
+		want string
+	}{
+		{time.October, "gent", "октября"},
+		{time.March, "gent", "марта"},
+		{time.May, "loct", "мае"},
+		{time.January, "datv", "январю"},
+	}
+
+	for _, tt := range tests {
+		got, ok := f.Month(tt.m, tt.cas)
+		if !ok || got != tt.want {
+			t.Errorf("Month(%v, %q) = %q, %v; want %q", tt.m, tt.cas, got, ok, tt.want)
+		}
+	}
+
+	if _, ok := f.Month(13, "gent"); ok {
+		t.Error("Month(13) should fail")
+	}
+}
+
+func TestWeekdayWith(t *testing.T) {
+	f := testFormatter
+
+	tests := []struct {
+		prep string
+		d    time.Weekday
+		want string
+	}{
+		{"в", time.Monday, "в понедельник"},
+		{"в", time.Wednesday, "в среду"},
+		{"к", time.Friday, "к пятнице"},
+		{"в", time.Tuesday, "во вторник"},
+		{"с", time.Tuesday, "со вторника"},
+		{"о", time.Monday, "о понедельнике"},
+		{"по", time.Friday, "по пятницу"},
+		{"до", time.Sunday, "до воскресенья"},
+	}
+
+	for _, tt := range tests {
+		got, ok := f.WeekdayWith(tt.prep, tt.d)
+		if !ok || got != tt.want {
+			t.Errorf("WeekdayWith(%q, %v) = %q, %v; want %q", tt.prep, tt.d, got, ok, tt.want)
+		}
+	}
+
+	if _, ok := f.WeekdayWith("вдруг", time.Monday); ok {
+		t.Error("WeekdayWith with a non-preposition should fail")
+	}
+}
+
+func TestDate(t *testing.T) {
+	tests := []struct {
+		f    Formatter
+		cas  string
+		want string
+	}{
+		{Formatter{}, "nomn", "16 октября 2026 года"},
+		{Formatter{OmitYear: true}, "nomn", "16 октября"},
+		{Formatter{SpellDay: true}, "nomn", "шестнадцатое октября 2026 года"},
+		{Formatter{SpellDay: true, OmitYear: true}, "gent", "шестнадцатого октября"},
+		{Formatter{SpellDay: true, OmitYear: true}, "datv", "шестнадцатому октября"},
+		{Formatter{SpellDay: true, SpellYear: true}, "nomn", "шестнадцатое октября две тысячи двадцать шестого года"},
+	}
+
+	for _, tt := range tests {
+		f := tt.f
+		f.a = testFormatter.a
+		got, ok := f.Date(date(2026, time.October, 16), tt.cas)
+		if !ok || got != tt.want {
+			t.Errorf("Date(%+v, %q) = %q, %v; want %q", tt.f, tt.cas, got, ok, tt.want)
+		}
+	}
+}
+
+func TestRange(t *testing.T) {
+	tests := []struct {
+		f        Formatter
+		from, to time.Time
+		want     string
+	}{
+		{Formatter{OmitYear: true}, date(2026, time.March, 3), date(2026, time.March, 10), "с 3 по 10 марта"},
+		{Formatter{}, date(2026, time.March, 3), date(2026, time.March, 10), "с 3 по 10 марта 2026 года"},
+		{Formatter{}, date(2026, time.February, 28), date(2026, time.March, 3), "с 28 февраля по 3 марта 2026 года"},
+		{Formatter{}, date(2025, time.December, 28), date(2026, time.January, 3), "с 28 декабря 2025 года по 3 января 2026 года"},
+		{Formatter{SpellDay: true, OmitYear: true}, date(2026, time.March, 3), date(2026, time.March, 10), "с третьего по десятое марта"},
+		{Formatter{SpellDay: true, OmitYear: true}, date(2026, time.March, 2), date(2026, time.March, 10), "со второго по десятое марта"},
+	}
+
+	for _, tt := range tests {
+		f := tt.f
+		f.a = testFormatter.a
+		got, ok := f.Range(tt.from, tt.to)
+		if !ok || got != tt.want {
+			t.Errorf("Range(%v, %v) = %q, %v; want %q", tt.from, tt.to, got, ok, tt.want)
+		}
+	}
+}
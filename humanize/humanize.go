@@ -0,0 +1,137 @@
+// Package humanize writes durations and time differences as Russian
+// phrases, agreeing every unit noun with its number through the gomorphy
+// analyzer:
+//
+//	h := humanize.New(a)
+//	h.Ago(3 * time.Minute)             // "3 минуты назад"
+//	h.In(2 * time.Hour)                // "через 2 часа"
+//	h.Duration(29*time.Hour, "nomn")   // "1 день 5 часов"
+//	h.Duration(5*time.Minute, "gent")  // "5 минут", as in "в течение 5 минут"
+package humanize
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jus1d/gomorphy"
+)
+
+// Calendar units are approximated by fixed lengths
+const (
+	day   = 24 * time.Hour
+	month = 30 * day
+	year  = 365 * day
+)
+
+// unit is a span of time named by a noun in its dictionary form
+type unit struct {
+	length time.Duration
+	noun   string
+}
+
+// units are ordered from the longest
+var units = [...]unit{
+	{year, "год"},
+	{month, "месяц"},
+	{day, "день"},
+	{time.Hour, "час"},
+	{time.Minute, "минута"},
+	{time.Second, "секунда"},
+}
+
+// Humanizer formats durations with the analyzer it was created with
+type Humanizer struct {
+	a *gomorphy.Analyzer
+
+	// Precision is the largest number of units [Humanizer.Duration] writes,
+	// 2 when zero: "1 день 5 часов" rather than "1 день 5 часов 3 минуты"
+	Precision int
+}
+
+// New returns a Humanizer using the analyzer a
+func New(a *gomorphy.Analyzer) *Humanizer {
+	return &Humanizer{a: a}
+}
+
+// Duration writes d with its largest non-zero units, each followed by its
+// noun agreeing with the number, declined to cas: "1 день 5 часов" in the
+// nominative, "1 дня 5 часов" in the genitive after "в течение", "2 дня"
+// in the accusative after "за". Smaller remainders are dropped and the sign
+// of d is ignored
+// Reports false for an unsupported case
+func (h *Humanizer) Duration(d time.Duration, cas string) (string, bool) {
+	precision := h.Precision
+	if precision <= 0 {
+		precision = 2
+	}
+	return h.spell(d, cas, precision)
+}
+
+// Ago writes how long ago something happened d before now using its
+// largest unit: "3 минуты назад"; under a second is "только что"
+func (h *Humanizer) Ago(d time.Duration) (string, bool) {
+	if d.Abs() < time.Second {
+		return "только что", true
+	}
+	s, ok := h.spell(d, "accs", 1)
+	if !ok {
+		return "", false
+	}
+	return s + " назад", true
+}
+
+// In writes how soon something happens d after now using its largest
+// unit: "через 2 часа"; under a second is "сейчас"
+func (h *Humanizer) In(d time.Duration) (string, bool) {
+	if d.Abs() < time.Second {
+		return "сейчас", true
+	}
+	s, ok := h.spell(d, "accs", 1)
+	if !ok {
+		return "", false
+	}
+	return "через " + s, true
+}
+
+// Relative writes t relative to now with [Humanizer.Ago] or [Humanizer.In]
+func (h *Humanizer) Relative(t, now time.Time) (string, bool) {
+	if t.Before(now) {
+		return h.Ago(now.Sub(t))
+	}
+	return h.In(t.Sub(now))
+}
+
+// spell writes at most precision units of d, largest first
+func (h *Humanizer) spell(d time.Duration, cas string, precision int) (string, bool) {
+	d = d.Abs()
+	var parts []string
+	for _, u := range units {
+		if len(parts) == precision {
+			break
+		}
+		n := d / u.length
+		if n == 0 {
+			continue
+		}
+		d -= n * u.length
+		s, ok := h.count(int64(n), u.noun, cas)
+		if !ok {
+			return "", false
+		}
+		parts = append(parts, s)
+	}
+	if len(parts) == 0 {
+		return h.count(0, "секунда", cas)
+	}
+	return strings.Join(parts, " "), true
+}
+
+// count writes n in digits followed by noun agreeing with it in cas
+func (h *Humanizer) count(n int64, noun, cas string) (string, bool) {
+	s, ok := h.a.CountPhrase(n, noun, cas)
+	if !ok {
+		return "", false
+	}
+	return strconv.FormatInt(n, 10) + " " + s, true
+}
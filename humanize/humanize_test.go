@@ -0,0 +1,89 @@
+package humanize
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jus1d/gomorphy"
+)
+
+// shared humanizer reused across all tests
+var testHumanizer = func() *Humanizer {
+	a, err := gomorphy.Default()
+	if err != nil {
+		panic("failed to load analyzer: " + err.Error())
+	}
+	return New(a)
+}()
+
+func TestDuration(t *testing.T) {
+	h := testHumanizer
+
+	tests := []struct {
+		d    time.Duration
+		cas  string
+		want string
+	}{
+		{29 * time.Hour, "nomn", "1 день 5 часов"},
+		{29*time.Hour + 3*time.Minute, "nomn", "1 день 5 часов"},
+		{5 * time.Minute, "gent", "5 минут"},
+		{2 * day, "accs", "2 дня"},
+		{21 * time.Minute, "nomn", "21 минута"},
+		{90 * time.Second, "ablt", "1 минутой 30 секундами"},
+		{5 * year, "nomn", "5 лет"},
+		{2 * year, "nomn", "2 года"},
+		{0, "nomn", "0 секунд"},
+		{-3 * time.Hour, "nomn", "3 часа"},
+	}
+
+	for _, tt := range tests {
+		got, ok := h.Duration(tt.d, tt.cas)
+		if !ok || got != tt.want {
+			t.Errorf("Duration(%v, %q) = %q, %v; want %q", tt.d, tt.cas, got, ok, tt.want)
+		}
+	}
+
+	precise := *h
+	precise.Precision = 3
+	if got, _ := precise.Duration(29*time.Hour+3*time.Minute, "nomn"); got != "1 день 5 часов 3 минуты" {
+		t.Errorf("Duration with Precision 3 = %q", got)
+	}
+}
+
+func TestAgoIn(t *testing.T) {
+	h := testHumanizer
+
+	tests := []struct {
+		name string
+		fn   func(time.Duration) (string, bool)
+		d    time.Duration
+		want string
+	}{
+		{"Ago", h.Ago, 3 * time.Minute, "3 минуты назад"},
+		{"Ago", h.Ago, 1 * time.Minute, "1 минуту назад"},
+		{"Ago", h.Ago, 26 * time.Hour, "1 день назад"},
+		{"Ago", h.Ago, 0, "только что"},
+		{"In", h.In, 2 * time.Hour, "через 2 часа"},
+		{"In", h.In, 11 * time.Second, "через 11 секунд"},
+		{"In", h.In, 0, "сейчас"},
+	}
+
+	for _, tt := range tests {
+		got, ok := tt.fn(tt.d)
+		if !ok || got != tt.want {
+			t.Errorf("%s(%v) = %q, %v; want %q", tt.name, tt.d, got, ok, tt.want)
+		}
+	}
+}
+
+func TestRelative(t *testing.T) {
+	h := testHumanizer
+	now := time.Date(2026, time.October, 18, 12, 0, 0, 0, time.UTC)
+
+	if got, _ := h.Relative(now.Add(-3*time.Minute), now); got != "3 минуты назад" {
+		t.Errorf("Relative(past) = %q, want %q", got, "3 минуты назад")
+	}
+	if got, _ := h.Relative(now.Add(2*time.Hour), now); got != "через 2 часа" {
+		t.Errorf("Relative(future) = %q, want %q", got, "через 2 часа")
+	}
+}
@@ -0,0 +1,83 @@
+package gomorphy
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Currency names a monetary unit and its hundredth part by their dictionary
+// forms. Gender and declinability come from the dictionary, so any pair of
+// nouns it knows can be used
+type Currency struct {
+	Unit    string
+	Subunit string
+}
+
+// Common currencies
+var (
+	Rouble = Currency{Unit: "рубль", Subunit: "копейка"}
+	Dollar = Currency{Unit: "доллар", Subunit: "цент"}
+	Euro   = Currency{Unit: "евро", Subunit: "цент"}
+)
+
+// SpellMoney writes an amount given in hundredths of the currency unit out
+// the way accounting documents require: the whole units in words, the
+// hundredths as two digits, each followed by its noun in the agreeing form,
+// e.g. SpellMoney(125005, Rouble, "nomn") →
+// "одна тысяча двести пятьдесят рублей 05 копеек"
+//
+// cas declines the whole amount for its place in a sentence, e.g. "accs"
+// after "на сумму" gives "одну тысячу двести пятьдесят рублей 05 копеек".
+// Indeclinable units such as "евро" are left as they are
+// Reports false for an unsupported case or a noun missing from the dictionary
+func (a *Analyzer) SpellMoney(amount int64, c Currency, cas string) (string, bool) {
+	if !slices.Contains(phraseCases, cas) {
+		return "", false
+	}
+	unitGender, ok := a.nounGender(c.Unit)
+	if !ok {
+		return "", false
+	}
+	subGender, ok := a.nounGender(c.Subunit)
+	if !ok {
+		return "", false
+	}
+
+	var words []string
+	u := uint64(amount)
+	if amount < 0 {
+		words = append(words, "минус")
+		u = -u
+	}
+	units, sub := u/100, u%100
+
+	number, ok := a.SpellNumber(int64(units), cas, unitGender, "inan")
+	if !ok {
+		return "", false
+	}
+	unit, ok := a.countedNoun(c.Unit, unitGender, units, cas)
+	if !ok {
+		return "", false
+	}
+	subunit, ok := a.countedNoun(c.Subunit, subGender, sub, cas)
+	if !ok {
+		return "", false
+	}
+	words = append(words, number, unit, fmt.Sprintf("%02d", sub), subunit)
+	return strings.Join(words, " "), true
+}
+
+// nounGender returns the dictionary gender of a noun lemma
+// Nouns without a gender, such as plural-only ones, report false
+func (a *Analyzer) nounGender(lemma string) (string, bool) {
+	for _, p := range a.parses(lemma) {
+		if tagPOS(p.tag) != "NOUN" {
+			continue
+		}
+		if g := tagGrammeme(p.tag, genderGrammemes); g != "" {
+			return g, true
+		}
+	}
+	return "", false
+}
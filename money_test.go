@@ -0,0 +1,37 @@
+package gomorphy
+
+import "testing"
+
+func TestSpellMoney(t *testing.T) {
+	a := testAnalyzer
+
+	tests := []struct {
+		amount int64
+		c      Currency
+		cas    string
+		want   string
+	}{
+		{125005, Rouble, "nomn", "одна тысяча двести пятьдесят рублей 05 копеек"},
+		{125005, Rouble, "accs", "одну тысячу двести пятьдесят рублей 05 копеек"},
+		{125005, Rouble, "gent", "одной тысячи двухсот пятидесяти рублей 05 копеек"},
+		{201, Rouble, "nomn", "два рубля 01 копейка"},
+		{2102, Rouble, "nomn", "двадцать один рубль 02 копейки"},
+		{100, Rouble, "datv", "одному рублю 00 копейкам"},
+		{0, Rouble, "nomn", "ноль рублей 00 копеек"},
+		{-350, Rouble, "nomn", "минус три рубля 50 копеек"},
+		{500, Dollar, "nomn", "пять долларов 00 центов"},
+		{299, Euro, "nomn", "два евро 99 центов"},
+		{2100, Euro, "ablt", "двадцатью одним евро 00 центами"},
+	}
+
+	for _, tt := range tests {
+		got, ok := a.SpellMoney(tt.amount, tt.c, tt.cas)
+		if !ok || got != tt.want {
+			t.Errorf("SpellMoney(%d, %v, %q) = %q, %v; want %q", tt.amount, tt.c, tt.cas, got, ok, tt.want)
+		}
+	}
+
+	if _, ok := a.SpellMoney(100, Currency{Unit: "тугрикус", Subunit: "копейка"}, "nomn"); ok {
+		t.Error("SpellMoney with an unknown unit should fail")
+	}
+}
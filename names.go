@@ -0,0 +1,414 @@
+package gomorphy
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Name part roles, named after the OpenCorpora grammemes that mark them
+const (
+	roleName = "Name"
+	rolePatr = "Patr"
+	roleSurn = "Surn"
+)
+
+// nameCases are the cases a personal name can be declined to
+var nameCases = map[string]bool{
+	"nomn": true, "gent": true, "datv": true, "accs": true, "ablt": true, "loct": true,
+	"gen2": true, "loc2": true, "voct": true,
+}
+
+// InflectName declines a personal name to the given case, e.g.
+// InflectName("Иван Петрович Сидоров", "datv", "") → "Ивану Петровичу Сидорову"
+// and InflectName("Анна Сергеевна Ким", "gent", "") → "Анны Сергеевны Ким"
+//
+// The name may combine a first name, patronymic and surname in any order;
+// parts are recognised by the dictionary's Name, Patr and Surn grammemes, and
+// whatever is left is taken for a surname. Surnames missing from the
+// dictionary are declined by their endings (-ов/-ова, -ин/-ина, -ский/-ская,
+// -ян, ...), while -ко, -их/-ых and, for women, consonant endings stay
+// unchanged. Hyphenated parts are declined piecewise and every part keeps
+// its original capitalisation
+//
+// gender is "masc" or "femn"; an empty string detects it with
+// [Analyzer.GuessGender], assuming "masc" when the name does not tell
+// The vocative uses the dictionary form where there is one ("Маш") and
+// the nominative otherwise; gen2 and loc2 likewise fall back to gent and loct
+// Reports false for an empty name or a case other than nomn, gent, datv,
+// accs, ablt, loct, gen2, loc2 or voct
+func (a *Analyzer) InflectName(name, cas, gender string) (string, bool) {
+	parts := strings.Fields(name)
+	if len(parts) == 0 || !nameCases[cas] {
+		return "", false
+	}
+	roles := a.nameRoles(parts)
+	if gender == "" {
+		if gender, _ = a.nameGender(parts, roles); gender == "" {
+			gender = "masc"
+		}
+	}
+
+	out := make([]string, len(parts))
+	for i, part := range parts {
+		pieces := strings.Split(part, "-")
+		for j, piece := range pieces {
+			form := a.inflectNamePart(strings.ToLower(piece), roles[i], cas, gender)
+			pieces[j] = matchCapitalization(piece, form)
+		}
+		out[i] = strings.Join(pieces, "-")
+	}
+	return strings.Join(out, " "), true
+}
+
+// IsPersonalName reports whether text reads as a personal name, e.g.
+// "Иван Петров" or "мария" but not "Новое сообщение"
+//
+// Every part must have a Name, Patr or Surn parse or be missing from the
+// dictionary altogether, as unknown surnames are, and at least one part must
+// have such a parse. Capitalisation is ignored
+func (a *Analyzer) IsPersonalName(text string) bool {
+	known := false
+	for _, part := range strings.Fields(text) {
+		for _, piece := range strings.Split(strings.ToLower(part), "-") {
+			switch {
+			case a.hasRole(piece, roleName) || a.hasRole(piece, rolePatr) || a.hasRole(piece, roleSurn):
+				known = true
+			case len(a.parses(piece)) > 0:
+				return false
+			}
+		}
+	}
+	return known
+}
+
+// nameRoles assigns a role to every part of a personal name: words with a
+// patronymic parse are patronymics, the first remaining word with a first
+// name parse is the first name and everything else is a surname
+func (a *Analyzer) nameRoles(parts []string) []string {
+	roles := make([]string, len(parts))
+	for i, part := range parts {
+		if a.hasRole(strings.ToLower(part), rolePatr) {
+			roles[i] = rolePatr
+		}
+	}
+	haveName := false
+	for i, part := range parts {
+		if roles[i] != "" {
+			continue
+		}
+		if !haveName && a.hasRole(strings.ToLower(part), roleName) {
+			roles[i] = roleName
+			haveName = true
+			continue
+		}
+		roles[i] = roleSurn
+	}
+	return roles
+}
+
+// hasRole reports whether word has a parse carrying the given name grammeme
+func (a *Analyzer) hasRole(word, role string) bool {
+	for _, p := range a.parses(word) {
+		if strings.Contains(p.tag, role) {
+			return true
+		}
+	}
+	return false
+}
+
+// GuessGender guesses the gender of a person from their name, e.g.
+// "Иванова Мария Петровна" → "femn", "Саша Ким" → ""
+//
+// Every part of the name votes with the gender grammeme of its Name, Patr or
+// Surn parses, or with its ending when the dictionary does not know it
+// (-ович/-овна, -ов/-ова, -ский/-ская, ...). A patronymic outweighs a first
+// name, which outweighs a surname, and ending-based votes count half.
+// Common-gender names such as "Саша" or "Женя" cast no vote, so they are
+// decided by the other parts if at all
+//
+// Returns "masc" or "femn" with a confidence in (0, 1), or an empty string
+// and zero confidence when the name gives no evidence or the votes tie
+// The confidence approaches 1 as more parts agree but never reaches it:
+// a full agreeing name such as "Иванова Мария Петровна" gives about 0.92
+func (a *Analyzer) GuessGender(name string) (string, float64) {
+	parts := strings.Fields(name)
+	if len(parts) == 0 {
+		return "", 0
+	}
+	return a.nameGender(parts, a.nameRoles(parts))
+}
+
+// genderWeights is the weight of a dictionary vote cast by each name part
+var genderWeights = map[string]float64{rolePatr: 3, roleName: 2, roleSurn: 1}
+
+// nameGender tallies the gender votes of the parts of a name, see [Analyzer.GuessGender]
+func (a *Analyzer) nameGender(parts, roles []string) (string, float64) {
+	var masc, femn float64
+	for i, part := range parts {
+		w := genderWeights[roles[i]]
+		g, fromDict := a.roleGender(strings.ToLower(part), roles[i])
+		if !fromDict {
+			w /= 2
+		}
+		switch g {
+		case "masc":
+			masc += w
+		case "femn":
+			femn += w
+		}
+	}
+	switch {
+	case masc > femn:
+		return "masc", (masc - femn) / (masc + femn + 0.5)
+	case femn > masc:
+		return "femn", (femn - masc) / (masc + femn + 0.5)
+	}
+	return "", 0
+}
+
+// roleGender returns the gender of word read in the given role and whether it
+// comes from the dictionary. Nominative parses are preferred, so "Иванова"
+// is the woman's surname rather than the genitive of "Иванов". Words missing
+// from the dictionary in that role fall back to their ending
+// Returns an empty gender when the parses disagree or are of common gender
+func (a *Analyzer) roleGender(word, role string) (string, bool) {
+	var all, nominative []string
+	for _, p := range a.parses(word) {
+		if !strings.Contains(p.tag, role) {
+			continue
+		}
+		g := tagGrammeme(p.tag, genderGrammemes)
+		all = append(all, g)
+		if strings.Contains(p.tag, "nomn") {
+			nominative = append(nominative, g)
+		}
+	}
+	if len(all) == 0 {
+		return suffixGender(word), false
+	}
+	if len(nominative) > 0 {
+		all = nominative
+	}
+	for _, g := range all[1:] {
+		if g != all[0] {
+			return "", true
+		}
+	}
+	return all[0], true
+}
+
+// femnNameSuffixes and mascNameSuffixes are the endings that reveal the gender
+// of a surname or patronymic
+var (
+	femnNameSuffixes = []string{"овна", "евна", "ична", "инична", "ова", "ева", "ёва", "ина", "ына", "ская", "цкая", "ая"}
+	mascNameSuffixes = []string{"ович", "евич", "ич", "ов", "ев", "ёв", "ин", "ын", "ский", "цкий", "ой", "ый", "ий"}
+)
+
+// suffixGender returns the gender suggested by the ending of a surname or
+// patronymic, or an empty string when the ending is not telling
+func suffixGender(word string) string {
+	for _, s := range femnNameSuffixes {
+		if strings.HasSuffix(word, s) {
+			return "femn"
+		}
+	}
+	for _, s := range mascNameSuffixes {
+		if strings.HasSuffix(word, s) {
+			return "masc"
+		}
+	}
+	return ""
+}
+
+// inflectNamePart declines a single lower-case name part, preferring a
+// dictionary parse with the right role and gender and falling back to
+// the ending rules
+func (a *Analyzer) inflectNamePart(word, role, cas, gender string) string {
+	for _, p := range a.parses(word) {
+		if !strings.Contains(p.tag, role) {
+			continue
+		}
+		g := tagGrammeme(p.tag, genderGrammemes)
+		if g != "" && g != gender {
+			continue
+		}
+		if strings.Contains(p.tag, "Fixd") {
+			return word
+		}
+		// Surname and patronymic lexemes hold both genders ("Иванов",
+		// "Иванова"), so the form is looked up in the gender of the parse;
+		// common-gender names ("Саша") have none to match
+		if f, ok := a.inflectEntry(word, p.entry, cas, "sing", g, ""); ok {
+			return f
+		}
+		if f, ok := a.inflectEntry(word, p.entry, baseCase(cas), "sing", g, ""); ok {
+			return f
+		}
+	}
+	return inflectNameByRules(word, role, baseCase(cas), gender)
+}
+
+// nameEnding is one row of the fallback declension table: the nominative
+// ending and the endings replacing it in gent, datv, accs, ablt and loct
+type nameEnding struct {
+	nomn                         string
+	gent, datv, accs, ablt, loct string
+}
+
+// mascSurnameEndings and femnSurnameEndings cover the possessive (-ов, -ин)
+// and adjectival (-ский, -ой) surnames missing from the dictionary. They are
+// checked in order, so longer endings come first
+var (
+	mascSurnameEndings = []nameEnding{
+		{"ский", "ского", "скому", "ского", "ским", "ском"},
+		{"цкий", "цкого", "цкому", "цкого", "цким", "цком"},
+		{"ий", "ого", "ому", "ого", "им", "ом"},
+		{"ый", "ого", "ому", "ого", "ым", "ом"},
+		{"ой", "ого", "ому", "ого", "ым", "ом"},
+		{"ов", "ова", "ову", "ова", "овым", "ове"},
+		{"ев", "ева", "еву", "ева", "евым", "еве"},
+		{"ёв", "ёва", "ёву", "ёва", "ёвым", "ёве"},
+		{"ин", "ина", "ину", "ина", "иным", "ине"},
+		{"ын", "ына", "ыну", "ына", "ыным", "ыне"},
+	}
+	femnSurnameEndings = []nameEnding{
+		{"ская", "ской", "ской", "скую", "ской", "ской"},
+		{"цкая", "цкой", "цкой", "цкую", "цкой", "цкой"},
+		{"ая", "ой", "ой", "ую", "ой", "ой"},
+		{"ова", "овой", "овой", "ову", "овой", "овой"},
+		{"ева", "евой", "евой", "еву", "евой", "евой"},
+		{"ёва", "ёвой", "ёвой", "ёву", "ёвой", "ёвой"},
+		{"ина", "иной", "иной", "ину", "иной", "иной"},
+		{"ына", "ыной", "ыной", "ыну", "ыной", "ыной"},
+	}
+)
+
+// nounNameEndings decline names that follow the noun declensions
+var nounNameEndings = []nameEnding{
+	{"ия", "ии", "ии", "ию", "ией", "ии"},
+	{"ь", "я", "ю", "я", "ем", "е"},
+	{"й", "я", "ю", "я", "ем", "е"},
+}
+
+// inflectNameByRules declines a lower-case name part by its ending
+// Parts ending in -о, -е, -и, -у, -ю, -э, -ых/-их, and women's parts ending
+// in a consonant, are indeclinable and returned unchanged
+func inflectNameByRules(word, role, cas, gender string) string {
+	if cas == "nomn" {
+		return word
+	}
+	var table []nameEnding
+	if role == roleSurn {
+		table = mascSurnameEndings
+		if gender == "femn" {
+			table = femnSurnameEndings
+		}
+	}
+	if gender != "femn" {
+		table = append(table[:len(table):len(table)], nounNameEndings...)
+	} else {
+		table = append(table[:len(table):len(table)], nounNameEndings[0])
+	}
+	for _, e := range table {
+		if strings.HasSuffix(word, e.nomn) {
+			return strings.TrimSuffix(word, e.nomn) + e.ending(cas)
+		}
+	}
+
+	if strings.HasSuffix(word, "их") || strings.HasSuffix(word, "ых") {
+		return word
+	}
+	last, _ := utf8.DecodeLastRuneInString(word)
+	stem := word[:len(word)-utf8.RuneLen(last)]
+	switch {
+	case last == 'а' || last == 'я':
+		return stem + firstDeclensionEnding(stem, last, cas)
+	case strings.ContainsRune("оеиуюэыё", last):
+		return word
+	case gender == "femn":
+		return word
+	}
+
+	// Masculine consonant ending: "Ким" → "Кима", "Петрович" → "Петровича"
+	switch cas {
+	case "gent", "accs":
+		return word + "а"
+	case "datv":
+		return word + "у"
+	case "ablt":
+		if strings.ContainsRune("жшщчц", last) && !stressedEndings[word] {
+			return word + "ем"
+		}
+		return word + "ом"
+	}
+	return word + "е"
+}
+
+// stressedEndings are the patronymics with a stressed ending after a
+// sibilant, which take -ом rather than -ем: "Ильичом", "Кузьмичом"
+var stressedEndings = map[string]bool{
+	"ильич": true, "кузьмич": true, "лукич": true, "фомич": true,
+}
+
+// ending returns the ending of e for cas
+func (e nameEnding) ending(cas string) string {
+	switch cas {
+	case "gent":
+		return e.gent
+	case "datv":
+		return e.datv
+	case "accs":
+		return e.accs
+	case "ablt":
+		return e.ablt
+	case "loct":
+		return e.loct
+	}
+	return e.nomn
+}
+
+// firstDeclensionEnding returns the ending of a name in -а/-я for cas,
+// e.g. "Гулыга" → "Гулыги", "Петровна" → "Петровне"
+func firstDeclensionEnding(stem string, last rune, cas string) string {
+	prev, _ := utf8.DecodeLastRuneInString(stem)
+	velarOrSibilant := strings.ContainsRune("гкхжшщч", prev)
+	soft := last == 'я'
+	switch cas {
+	case "gent":
+		if soft || velarOrSibilant {
+			return "и"
+		}
+		return "ы"
+	case "datv", "loct":
+		return "е"
+	case "accs":
+		if soft {
+			return "ю"
+		}
+		return "у"
+	case "ablt":
+		if soft || strings.ContainsRune("жшщчц", prev) {
+			return "ей"
+		}
+		return "ой"
+	}
+	return string(last)
+}
+
+// matchCapitalization applies the capitalisation of orig to form:
+// all upper case, title case or lower case
+func matchCapitalization(orig, form string) string {
+	if orig == "" || form == "" {
+		return form
+	}
+	if strings.ToUpper(orig) == orig && utf8.RuneCountInString(orig) > 1 {
+		return strings.ToUpper(form)
+	}
+	first, _ := utf8.DecodeRuneInString(orig)
+	if unicode.IsUpper(first) {
+		r, size := utf8.DecodeRuneInString(form)
+		return string(unicode.ToUpper(r)) + form[size:]
+	}
+	return form
+}
@@ -0,0 +1,159 @@
+package gomorphy
+
+import "testing"
+
+func TestInflectName(t *testing.T) {
+	a := testAnalyzer
+
+	tests := []struct {
+		name, cas, gender string
+		want              string
+	}{
+		{"Иван Петрович Сидоров", "datv", "", "Ивану Петровичу Сидорову"},
+		{"Сидоров Иван Петрович", "ablt", "", "Сидоровым Иваном Петровичем"},
+		{"Анна Сергеевна Ким", "gent", "", "Анны Сергеевны Ким"},
+		{"Иван Ким", "datv", "", "Ивану Киму"},
+		{"Мария Иванова", "accs", "", "Марию Иванову"},
+		{"Ольга Шевченко", "datv", "", "Ольге Шевченко"},
+		{"Пётр Черных", "gent", "masc", "Петра Черных"},
+		{"ИВАН СИДОРОВ", "gent", "", "ИВАНА СИДОРОВА"},
+		// Surname and patronymic lexemes hold both genders
+		{"Анна Сергеевна Иванова", "gent", "", "Анны Сергеевны Ивановой"},
+		{"Мария Петровна Сидорова", "ablt", "femn", "Марией Петровной Сидоровой"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name+"/"+tt.cas, func(t *testing.T) {
+			got, ok := a.InflectName(tt.name, tt.cas, tt.gender)
+			if !ok || got != tt.want {
+				t.Errorf("InflectName(%q, %q, %q) = %q, %v; want %q", tt.name, tt.cas, tt.gender, got, ok, tt.want)
+			}
+		})
+	}
+}
+
+func TestInflectName_EdgeCases(t *testing.T) {
+	a := testAnalyzer
+
+	t.Run("empty string", func(t *testing.T) {
+		if _, ok := a.InflectName("", "datv", ""); ok {
+			t.Error("InflectName(\"\") reported success")
+		}
+	})
+
+	t.Run("unsupported case", func(t *testing.T) {
+		if _, ok := a.InflectName("Иван", "instrumental", ""); ok {
+			t.Error("InflectName with an unknown case reported success")
+		}
+	})
+
+	t.Run("vocative", func(t *testing.T) {
+		if got, ok := a.InflectName("Маша", "voct", ""); !ok || got != "Маш" {
+			t.Errorf("InflectName(\"Маша\", voct) = %q, %v; want \"Маш\"", got, ok)
+		}
+		// Names without a vocative keep the nominative
+		if got, ok := a.InflectName("Иван Петров", "voct", ""); !ok || got != "Иван Петров" {
+			t.Errorf("InflectName(\"Иван Петров\", voct) = %q, %v; want \"Иван Петров\"", got, ok)
+		}
+	})
+}
+
+func TestInflectNameByRules(t *testing.T) {
+	tests := []struct {
+		word, role, cas, gender string
+		want                    string
+	}{
+		{"кудрявцев", roleSurn, "ablt", "masc", "кудрявцевым"},
+		{"кудрявцева", roleSurn, "datv", "femn", "кудрявцевой"},
+		{"жолковский", roleSurn, "gent", "masc", "жолковского"},
+		{"жолковская", roleSurn, "accs", "femn", "жолковскую"},
+		{"петросян", roleSurn, "ablt", "masc", "петросяном"},
+		{"петросян", roleSurn, "ablt", "femn", "петросян"},
+		{"гулыга", roleSurn, "gent", "masc", "гулыги"},
+		{"бондаренко", roleSurn, "datv", "masc", "бондаренко"},
+		{"долгих", roleSurn, "datv", "femn", "долгих"},
+		{"петрович", rolePatr, "ablt", "masc", "петровичем"},
+		{"ильич", rolePatr, "ablt", "masc", "ильичом"},
+		{"ильинична", rolePatr, "gent", "femn", "ильиничны"},
+	}
+	for _, tt := range tests {
+		if got := inflectNameByRules(tt.word, tt.role, tt.cas, tt.gender); got != tt.want {
+			t.Errorf("inflectNameByRules(%q, %q, %q, %q) = %q, want %q", tt.word, tt.role, tt.cas, tt.gender, got, tt.want)
+		}
+	}
+}
+
+func TestMatchCapitalization(t *testing.T) {
+	tests := []struct{ orig, form, want string }{
+		{"Иван", "ивану", "Ивану"},
+		{"ИВАН", "ивану", "ИВАНУ"},
+		{"иван", "ивану", "ивану"},
+		{"И", "ивану", "Ивану"},
+	}
+	for _, tt := range tests {
+		if got := matchCapitalization(tt.orig, tt.form); got != tt.want {
+			t.Errorf("matchCapitalization(%q, %q) = %q, want %q", tt.orig, tt.form, got, tt.want)
+		}
+	}
+}
+
+func TestGuessGender(t *testing.T) {
+	a := testAnalyzer
+
+	tests := []struct {
+		name    string
+		want    string
+		minConf float64
+	}{
+		{"Иванова Мария Петровна", "femn", 0.8},
+		{"Иван Петрович Сидоров", "masc", 0.8},
+		{"Сидоров", "masc", 0.3},
+		// Unknown surname decided by its ending alone
+		{"Кудрявцева", "femn", 0.1},
+		// Common-gender first name decided by the surname
+		{"Саша Иванова", "femn", 0.3},
+		{"Женя Петров", "masc", 0.3},
+		// Nothing decides
+		{"Саша", "", 0},
+		{"Женя", "", 0},
+		{"", "", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, conf := a.GuessGender(tt.name)
+			if got != tt.want {
+				t.Errorf("GuessGender(%q) = %q (%.2f), want %q", tt.name, got, conf, tt.want)
+			}
+			if conf < tt.minConf || conf >= 1 {
+				t.Errorf("GuessGender(%q) confidence = %.2f, want in [%.2f, 1)", tt.name, conf, tt.minConf)
+			}
+			if tt.want == "" && conf != 0 {
+				t.Errorf("GuessGender(%q) confidence = %.2f for unknown gender, want 0", tt.name, conf)
+			}
+		})
+	}
+}
+
+func TestIsPersonalName(t *testing.T) {
+	a := testAnalyzer
+
+	tests := []struct {
+		text string
+		want bool
+	}{
+		{"Иван Петров", true},
+		{"мария", true},
+		{"Анна-Мария Сидорова", true},
+		// An unknown surname next to a known first name
+		{"Иван Кудрявцев", true},
+		{"Новое сообщение", false},
+		{"Иван и сообщение", false},
+		{"", false},
+	}
+	for _, tt := range tests {
+		if got := a.IsPersonalName(tt.text); got != tt.want {
+			t.Errorf("IsPersonalName(%q) = %v, want %v", tt.text, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,783 @@
+package gomorphy
+
+import (
+	"math"
+	"math/bits"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Nominative lemmas of the cardinal components, indexed by digit
+var (
+	cardinalUnits    = [...]string{"", "один", "два", "три", "четыре", "пять", "шесть", "семь", "восемь", "девять"}
+	cardinalTeens    = [...]string{"десять", "одиннадцать", "двенадцать", "тринадцать", "четырнадцать", "пятнадцать", "шестнадцать", "семнадцать", "восемнадцать", "девятнадцать"}
+	cardinalTens     = [...]string{"", "", "двадцать", "тридцать", "сорок", "пятьдесят", "шестьдесят", "семьдесят", "восемьдесят", "девяносто"}
+	cardinalHundreds = [...]string{"", "сто", "двести", "триста", "четыреста", "пятьсот", "шестьсот", "семьсот", "восемьсот", "девятьсот"}
+)
+
+// Masculine nominative lemmas of the ordinal components, indexed by digit
+var (
+	ordinalUnits    = [...]string{"", "первый", "второй", "третий", "четвёртый", "пятый", "шестой", "седьмой", "восьмой", "девятый"}
+	ordinalTeens    = [...]string{"десятый", "одиннадцатый", "двенадцатый", "тринадцатый", "четырнадцатый", "пятнадцатый", "шестнадцатый", "семнадцатый", "восемнадцатый", "девятнадцатый"}
+	ordinalTens     = [...]string{"", "", "двадцатый", "тридцатый", "сороковой", "пятидесятый", "шестидесятый", "семидесятый", "восьмидесятый", "девяностый"}
+	ordinalHundreds = [...]string{"", "сотый", "двухсотый", "трёхсотый", "четырёхсотый", "пятисотый", "шестисотый", "семисотый", "восьмисотый", "девятисотый"}
+)
+
+// numberScale is a power of a thousand named by a noun that agrees with the
+// triple of digits in front of it like any counted noun
+type numberScale struct {
+	noun, gender, ordinal string
+}
+
+// numberScales are indexed by the power of a thousand; index 0 is the units
+// triple and has no noun. int64 needs no more than quintillions
+var numberScales = [...]numberScale{
+	{},
+	{"тысяча", "femn", "тысячный"},
+	{"миллион", "masc", "миллионный"},
+	{"миллиард", "masc", "миллиардный"},
+	{"триллион", "masc", "триллионный"},
+	{"квадриллион", "masc", "квадриллионный"},
+	{"квинтиллион", "masc", "квинтиллионный"},
+}
+
+// SpellNumber writes a cardinal number out in words, declined to the given
+// case and agreeing with a counted noun of the given gender and animacy, e.g.
+// SpellNumber(21, "nomn", "femn", "") → "двадцать одна" and
+// SpellNumber(21, "gent", "femn", "") → "двадцати одной"
+//
+// Every component is inflected through the dictionary. The scale nouns
+// тысяча, миллион, миллиард, ... agree with the digits in front of them
+// ("две тысячи", "пять миллионов"), and a leading one is spelled out
+// ("одна тысяча"), as required in documents. animacy only matters in the
+// accusative, where "один" and a bare 2–4 follow the genitive for animate
+// nouns; an empty animacy means inanimate
+// Reports false for a case other than nomn, gent, datv, accs, ablt or loct
+func (a *Analyzer) SpellNumber(n int64, cas, gender, animacy string) (string, bool) {
+	if !slices.Contains(phraseCases, cas) {
+		return "", false
+	}
+	if animacy == "" {
+		animacy = "inan"
+	}
+	if n == 0 {
+		return a.inflectLemma("ноль", cas, "sing")
+	}
+
+	var words []string
+	u := uint64(n)
+	if n < 0 {
+		words = append(words, "минус")
+		u = -u
+	}
+
+	// Bare 2–4 are the only numerals besides "один" marking animacy
+	fewAnimacy := "inan"
+	if u <= 4 {
+		fewAnimacy = animacy
+	}
+	triples := splitTriples(u)
+	for k := len(triples) - 1; k >= 0; k-- {
+		t := triples[k]
+		if t == 0 {
+			continue
+		}
+		g, an, fewAn := gender, animacy, fewAnimacy
+		if k > 0 {
+			g, an, fewAn = numberScales[k].gender, "inan", "inan"
+		}
+		tw, ok := a.spellTriple(t, cas, g, an, fewAn)
+		if !ok {
+			return "", false
+		}
+		words = append(words, tw...)
+		if k > 0 {
+			sw, ok := a.scaleNoun(numberScales[k], t, cas)
+			if !ok {
+				return "", false
+			}
+			words = append(words, sw)
+		}
+	}
+	return strings.Join(words, " "), true
+}
+
+// SpellOrdinal writes an ordinal number out in words, declined like an
+// adjective to the given case, number, gender and animacy, e.g.
+// SpellOrdinal(16, "gent", "sing", "masc", "") → "шестнадцатого" and
+// SpellOrdinal(2026, "nomn", "sing", "masc", "") → "две тысячи двадцать шестой"
+//
+// Only the last component becomes an ordinal; round thousands, millions, ...
+// fuse into one word ("двухтысячный", "стомиллионный"). An empty animacy
+// means inanimate
+// Reports false for a negative number or a case other than nomn, gent,
+// datv, accs, ablt or loct
+func (a *Analyzer) SpellOrdinal(n int64, cas, number, gender, animacy string) (string, bool) {
+	if n < 0 || !slices.Contains(phraseCases, cas) {
+		return "", false
+	}
+	if animacy == "" {
+		animacy = "inan"
+	}
+	decline := func(lemma string) (string, bool) {
+		c, g := adjAgreement(cas, number, gender, animacy)
+		return a.inflectLemmaAdj(lemma, c, number, g)
+	}
+	if n == 0 {
+		return decline("нулевой")
+	}
+
+	triples := splitTriples(uint64(n))
+	last := 0
+	for triples[last] == 0 {
+		last++
+	}
+
+	var words []string
+	for k := len(triples) - 1; k > last; k-- {
+		t := triples[k]
+		if t == 0 {
+			continue
+		}
+		// "тысяча девятьсот …", "миллион первый": a leading one is not spelled
+		if !(k == len(triples)-1 && t == 1) {
+			tw, ok := a.spellTriple(t, "nomn", numberScales[k].gender, "inan", "inan")
+			if !ok {
+				return "", false
+			}
+			words = append(words, tw...)
+		}
+		sw, ok := a.scaleNoun(numberScales[k], t, "nomn")
+		if !ok {
+			return "", false
+		}
+		words = append(words, sw)
+	}
+
+	t := triples[last]
+	if last > 0 {
+		prefix, ok := a.ordinalPrefix(t)
+		if !ok {
+			return "", false
+		}
+		f, ok := decline(numberScales[last].ordinal)
+		if !ok {
+			return "", false
+		}
+		return strings.Join(append(words, prefix+f), " "), true
+	}
+
+	lemmas := tripleLemmas(t)
+	for _, l := range lemmas[:len(lemmas)-1] {
+		words = append(words, l)
+	}
+	f, ok := decline(ordinalLemma(t))
+	if !ok {
+		return "", false
+	}
+	return strings.Join(append(words, f), " "), true
+}
+
+// SpellFraction writes a common fraction out in words declined to the given
+// case, e.g. SpellFraction(1, 2, "nomn") → "одна вторая",
+// SpellFraction(3, 5, "nomn") → "три пятых" and
+// SpellFraction(3, 5, "datv") → "трём пятым"
+//
+// The numerator agrees with the implied feminine noun "доля"; the
+// denominator is a feminine ordinal, singular after a numerator ending in
+// one and plural otherwise, in the genitive after a nominative or
+// accusative numerator
+// Reports false for a zero or negative denominator or an unsupported case
+func (a *Analyzer) SpellFraction(num, den int64, cas string) (string, bool) {
+	if den <= 0 {
+		return "", false
+	}
+	numerator, ok := a.SpellNumber(num, cas, "femn", "inan")
+	if !ok {
+		return "", false
+	}
+
+	abs := num
+	if abs < 0 {
+		abs = -abs
+	}
+	// Unlike a counted noun the denominator stays plural after 2–4:
+	// "три пятых", not "три пятой"
+	denCase, denNumber := cas, "sing"
+	if digitsCategory(strconv.FormatInt(abs, 10)) != "one" {
+		denNumber = "plur"
+		if c := baseCase(cas); c == "nomn" || c == "accs" {
+			denCase = "gent"
+		}
+	}
+	denominator, ok := a.SpellOrdinal(den, denCase, denNumber, "femn", "inan")
+	if !ok {
+		return "", false
+	}
+	return numerator + " " + denominator, true
+}
+
+// AbbrOrdinal writes an ordinal in digits with the ending the full ordinal
+// adjective takes in the given case, number, gender and animacy, e.g.
+// AbbrOrdinal(1, "nomn", "sing", "masc", "") → "1-й",
+// AbbrOrdinal(2, "gent", "sing", "masc", "") → "2-го" and
+// AbbrOrdinal(3, "loct", "sing", "masc", "") → "3-м"
+//
+// The ending is cut from the ordinal spelled through the dictionary: one
+// letter when it follows a vowel ("-й", "-я", "-е", "-ю", "-м", "-х"), two
+// when it follows a consonant ("-го", "-му", "-ми")
+// Reports false for a negative number or an unsupported case
+func (a *Analyzer) AbbrOrdinal(n int64, cas, number, gender, animacy string) (string, bool) {
+	spelled, ok := a.SpellOrdinal(n, cas, number, gender, animacy)
+	if !ok {
+		return "", false
+	}
+	return strconv.FormatInt(n, 10) + "-" + ordinalEnding(spelled), true
+}
+
+// AbbrOrdinalFor writes an ordinal in digits agreeing with the noun phrase
+// it stands before, e.g. AbbrOrdinalFor(3, "классе") → "3-м",
+// AbbrOrdinalFor(5, "улица") → "5-я" and AbbrOrdinalFor(2, "этажа") → "2-го"
+//
+// The case and number are read off the phrase as in
+// [Analyzer.PhraseReadings], taking the first reading when it is ambiguous
+// ("дом" is read as nominative), and the gender and animacy from its head
+// Reports false if the phrase has no noun or its words do not agree
+func (a *Analyzer) AbbrOrdinalFor(n int64, phrase string) (string, bool) {
+	words := strings.Fields(strings.ToLower(strings.TrimSpace(phrase)))
+	if len(words) == 0 {
+		return "", false
+	}
+	p := a.analyzePhrase(words)
+	if p.head == -1 {
+		return "", false
+	}
+	for _, r := range a.agreementReadings(p) {
+		tag := r[p.head].tag
+		cas := tagGrammeme(tag, caseGrammemes)
+		if !p.allows(cas) {
+			continue
+		}
+		return a.AbbrOrdinal(n, baseCase(cas), tagGrammeme(tag, numberGrammemes),
+			tagGrammeme(tag, genderGrammemes), tagGrammeme(tag, animacyGrammemes))
+	}
+	return "", false
+}
+
+// CountPhrase declines a noun phrase as counted by n, leaving the number
+// itself to the caller, e.g. CountPhrase(5, "новый файл", "nomn") →
+// "новых файлов" and CountPhrase(2, "минута", "gent") → "минут"
+//
+// The phrase may be given in any form; it follows the same numeral
+// government as a phrase with a numeral in front of it, see
+// [Analyzer.InflectPhrase]
+// Reports false if the phrase has no noun, already has a numeral, or a word
+// cannot be inflected
+func (a *Analyzer) CountPhrase(n int64, phrase, cas string) (string, bool) {
+	words := strings.Fields(strings.ToLower(strings.TrimSpace(phrase)))
+	if len(words) == 0 {
+		return "", false
+	}
+	p := a.analyzePhrase(words)
+	if p.head == -1 || p.numeral != nil {
+		return "", false
+	}
+	u := uint64(n)
+	if n < 0 {
+		u = -u
+	}
+	digits := strconv.FormatUint(u, 10)
+	p.numeral = &numeralGroup{digits: true, category: digitsCategory(digits), compound: len(digits) > 1}
+	return a.declinePhrase(p, cas, p.numeral.number())
+}
+
+// spellTriple spells a number from 1 to 999 declined to cas, with "один" and
+// "два" agreeing in gender; animacy applies to "один", fewAnimacy to 2–4
+func (a *Analyzer) spellTriple(t int, cas, gender, animacy, fewAnimacy string) ([]string, bool) {
+	lemmas := tripleLemmas(t)
+	words := make([]string, 0, len(lemmas))
+	for _, l := range lemmas {
+		an := "inan"
+		switch l {
+		case "один":
+			an = animacy
+		case "два", "три", "четыре":
+			an = fewAnimacy
+		}
+		entries := a.words.get(l)
+		np, ok := a.numeralParse(entries)
+		if !ok {
+			return nil, false
+		}
+		f, ok := a.inflectNumeral(l, np.entry, cas, gender, an)
+		if !ok {
+			return nil, false
+		}
+		words = append(words, f)
+	}
+	return words, true
+}
+
+// scaleNoun declines the noun of scale s counted by the triple t,
+// following the same government as any noun after a numeral
+func (a *Analyzer) scaleNoun(s numberScale, t int, cas string) (string, bool) {
+	return a.countedNoun(s.noun, s.gender, uint64(t), cas)
+}
+
+// countedNoun declines the inanimate noun lemma of the given gender as
+// counted by n in cas: "рубль" → "рубля" for 2 in the nominative
+func (a *Analyzer) countedNoun(lemma, gender string, n uint64, cas string) (string, bool) {
+	g := &numeralGroup{category: digitsCategory(strconv.FormatUint(n, 10)), compound: n > 9}
+	plan := g.plan(cas, gender, "inan")
+	return a.inflectLemma(lemma, plan.nounCase, plan.nounNumber)
+}
+
+// ordinalPrefix returns the first half of a fused ordinal such as
+// "двухтысячный" or "двадцатиоднотысячный": the triple in the genitive,
+// except "одно" for a trailing one and the invariable "сто" and "девяносто"
+// A single one gives an empty prefix ("тысячный")
+func (a *Analyzer) ordinalPrefix(t int) (string, bool) {
+	if t == 1 {
+		return "", true
+	}
+	var b strings.Builder
+	for _, l := range tripleLemmas(t) {
+		switch l {
+		case "один":
+			b.WriteString("одно")
+			continue
+		case "сто", "девяносто":
+			b.WriteString(l)
+			continue
+		}
+		np, ok := a.numeralParse(a.words.get(l))
+		if !ok {
+			return "", false
+		}
+		f, ok := a.inflectNumeral(l, np.entry, "gent", "", "")
+		if !ok {
+			return "", false
+		}
+		b.WriteString(f)
+	}
+	return b.String(), true
+}
+
+// inflectLemma declines a noun given in its dictionary form; indeclinable
+// nouns such as "евро" come back unchanged
+func (a *Analyzer) inflectLemma(lemma, cas, number string) (string, bool) {
+	for _, p := range a.parses(lemma) {
+		if !isNominal(tagPOS(p.tag)) {
+			continue
+		}
+		if strings.Contains(p.tag, "Fixd") {
+			return lemma, true
+		}
+		if f, ok := a.inflectEntry(lemma, p.entry, cas, number, "", ""); ok {
+			return f, true
+		}
+	}
+	return "", false
+}
+
+// inflectLemmaAdj declines an adjective given in its dictionary form;
+// cas and gender must already be mapped with [adjAgreement]
+func (a *Analyzer) inflectLemmaAdj(lemma, cas, number, gender string) (string, bool) {
+	for _, p := range a.parses(lemma) {
+		if tagPOS(p.tag) != "ADJF" {
+			continue
+		}
+		if f, ok := a.inflectEntry(lemma, p.entry, cas, number, gender, ""); ok {
+			return f, true
+		}
+	}
+	return "", false
+}
+
+// ordinalEnding returns the part of a spelled ordinal kept after the digits:
+// the last letter, or the last two when a consonant precedes it
+func ordinalEnding(spelled string) string {
+	r := []rune(spelled)
+	if len(r) < 2 || !strings.ContainsRune("бвгджзклмнпрстфхцчшщ", r[len(r)-2]) {
+		return string(r[len(r)-1:])
+	}
+	return string(r[len(r)-2:])
+}
+
+// tripleLemmas returns the nominative cardinal components of 1 ≤ t ≤ 999
+func tripleLemmas(t int) []string {
+	var lemmas []string
+	if h := t / 100; h > 0 {
+		lemmas = append(lemmas, cardinalHundreds[h])
+	}
+	switch r := t % 100; {
+	case r >= 10 && r < 20:
+		lemmas = append(lemmas, cardinalTeens[r-10])
+	default:
+		if r/10 > 0 {
+			lemmas = append(lemmas, cardinalTens[r/10])
+		}
+		if r%10 > 0 {
+			lemmas = append(lemmas, cardinalUnits[r%10])
+		}
+	}
+	return lemmas
+}
+
+// ordinalLemma returns the ordinal of the last component of 1 ≤ t ≤ 999
+func ordinalLemma(t int) string {
+	switch r := t % 100; {
+	case r >= 10 && r < 20:
+		return ordinalTeens[r-10]
+	case r%10 > 0:
+		return ordinalUnits[r%10]
+	case r > 0:
+		return ordinalTens[r/10]
+	}
+	return ordinalHundreds[t/100]
+}
+
+// splitTriples splits u into groups of three digits, least significant first
+func splitTriples(u uint64) []int {
+	var triples []int
+	for u > 0 {
+		triples = append(triples, int(u%1000))
+		u /= 1000
+	}
+	return triples
+}
+
+// NumberSpan is a number recognised in text by [Analyzer.FindNumbers]
+// Start and End are byte offsets of the consumed span, so text[Start:End]
+// is the number as written
+type NumberSpan struct {
+	Value   int64
+	Ordinal bool
+	Start   int
+	End     int
+}
+
+// numberToken is a run of letters or digits in text; spaced reports whether
+// only whitespace separates it from the previous token
+type numberToken struct {
+	text       string
+	start, end int
+	spaced     bool
+}
+
+// Values of the number words by their dictionary form, with ё spelled as е
+var (
+	cardinalValues = numberValues(cardinalUnits[:], cardinalTeens[:], cardinalTens[:], cardinalHundreds[:])
+	ordinalValues  = numberValues(ordinalUnits[:], ordinalTeens[:], ordinalTens[:], ordinalHundreds[:])
+)
+
+// ParseNumber finds the first number in text, written in words, digits or
+// both, and reports its value and span, e.g.
+// ParseNumber("оплата двумястами сорока пятью рублями") → 245 at [13:57)
+//
+// Every word is lemmatized through the dictionary, so any case form is
+// recognised ("двумястами" → "двести"), as are ordinals ("сто двадцать
+// третьего" → 123), scale words after digits ("3 тысячи" → 3000) and a
+// leading "минус"
+func (a *Analyzer) ParseNumber(text string) (NumberSpan, bool) {
+	tokens := numberTokens(text)
+	for i := range tokens {
+		if s, _, ok := a.parseNumberAt(tokens, i); ok {
+			return s, true
+		}
+	}
+	return NumberSpan{}, false
+}
+
+// FindNumbers returns every number in text in order of appearance; see
+// [Analyzer.ParseNumber] for what is recognised
+func (a *Analyzer) FindNumbers(text string) []NumberSpan {
+	tokens := numberTokens(text)
+	var spans []NumberSpan
+	for i := 0; i < len(tokens); {
+		s, next, ok := a.parseNumberAt(tokens, i)
+		if !ok {
+			i++
+			continue
+		}
+		spans = append(spans, s)
+		i = next
+	}
+	return spans
+}
+
+// parseNumberAt reads the longest number starting at tokens[i] and returns
+// it with the index of the first token after it. Components must come in
+// descending order ("сто двадцать три", not "три двадцать"); anything else
+// ends the number, as do punctuation, an ordinal and a word that would
+// overflow the value
+func (a *Analyzer) parseNumberAt(tokens []numberToken, i int) (NumberSpan, int, bool) {
+	span := NumberSpan{Start: tokens[i].start}
+	negative := false
+	if strings.ToLower(tokens[i].text) == "минус" && i+1 < len(tokens) && tokens[i+1].spaced {
+		negative = true
+		i++
+	}
+
+	var total, current uint64
+	scale := uint64(0) // the last scale used; the next one must be smaller
+	n := 0
+loop:
+	for j := i; j < len(tokens); j++ {
+		tok := tokens[j]
+		if j > i && !tok.spaced {
+			break
+		}
+		v, kind, ok := a.numberWord(tok.text)
+		if !ok {
+			break
+		}
+		switch kind {
+		case numberScaleWord:
+			if scale != 0 && v >= scale {
+				break loop
+			}
+			if current == 0 {
+				current = 1
+			}
+			hi, product := bits.Mul64(current, v)
+			sum, carry := bits.Add64(total, product, 0)
+			if hi != 0 || carry != 0 {
+				break loop
+			}
+			total = sum
+			current, scale = 0, v
+		case numberOrdinalScaleWord:
+			// Fused ordinals already carry their multiplier
+			if current != 0 || scale != 0 && v >= scale {
+				break loop
+			}
+			sum, carry := bits.Add64(total, v, 0)
+			if carry != 0 {
+				break loop
+			}
+			total = sum
+		default:
+			if !fitsComponent(current, v, kind) {
+				break loop
+			}
+			sum, carry := bits.Add64(current, v, 0)
+			if carry != 0 {
+				break loop
+			}
+			current = sum
+		}
+		n++
+		span.End = tok.end
+		if kind == numberOrdinalWord || kind == numberOrdinalScaleWord {
+			span.Ordinal = true
+			break
+		}
+	}
+	if n == 0 {
+		return NumberSpan{}, i, false
+	}
+
+	value, carry := bits.Add64(total, current, 0)
+	if carry != 0 || value > math.MaxInt64 {
+		return NumberSpan{}, i, false
+	}
+	span.Value = int64(value)
+	if negative {
+		span.Value = -span.Value
+	}
+	return span, i + n, true
+}
+
+// numberTokens splits text into runs of letters or digits
+func numberTokens(text string) []numberToken {
+	var tokens []numberToken
+	start, spaced := -1, false
+	for i, r := range text {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			if start < 0 {
+				start = i
+			}
+			continue
+		}
+		if start >= 0 {
+			tokens = append(tokens, numberToken{text: text[start:i], start: start, end: i, spaced: spaced})
+			start, spaced = -1, true
+		}
+		if !unicode.IsSpace(r) {
+			spaced = false
+		}
+	}
+	if start >= 0 {
+		tokens = append(tokens, numberToken{text: text[start:], start: start, end: len(text), spaced: spaced})
+	}
+	return tokens
+}
+
+// Kinds of number words
+const (
+	numberComponentWord    = iota // "двадцать", "пятью"
+	numberDigitsWord              // "245"
+	numberScaleWord               // "тысячи", "миллионов"
+	numberOrdinalWord             // "третьего"
+	numberOrdinalScaleWord        // "тысячного", "двухтысячный"
+)
+
+// numberWord returns the value of a single number word or digit string
+// Ordinal scales come back already multiplied ("двухтысячный" → 2000)
+func (a *Analyzer) numberWord(word string) (uint64, int, bool) {
+	if isDigits(word) {
+		v, err := strconv.ParseUint(word, 10, 64)
+		return v, numberDigitsWord, err == nil
+	}
+	w := strings.ToLower(word)
+	for _, lemma := range a.numberLemmas(w) {
+		l := strings.ReplaceAll(lemma, "ё", "е")
+		if v, ok := cardinalValues[l]; ok {
+			return v, numberComponentWord, true
+		}
+		if v, ok := ordinalValues[l]; ok {
+			return v, numberOrdinalWord, true
+		}
+		switch l {
+		case "ноль", "нуль":
+			return 0, numberComponentWord, true
+		case "нулевой":
+			return 0, numberOrdinalWord, true
+		}
+		for k := len(numberScales) - 1; k > 0; k-- {
+			v := pow1000(k)
+			s := numberScales[k]
+			if l == s.noun {
+				return v, numberScaleWord, true
+			}
+			if prefix, found := strings.CutSuffix(l, s.ordinal); found {
+				t, fused := a.fusedPrefixValue(prefix)
+				if !fused {
+					continue
+				}
+				return t * v, numberOrdinalScaleWord, true
+			}
+		}
+	}
+	return 0, 0, false
+}
+
+// numberLemmas returns the dictionary forms of word as a numeral, an ordinal
+// adjective or a noun, in that order, since "сорока" and "три" also have
+// unrelated readings
+func (a *Analyzer) numberLemmas(word string) []string {
+	var lemmas []string
+	ps := a.parses(word)
+	for _, p := range ps {
+		if tagPOS(p.tag) == "NUMR" {
+			if l, ok := a.inflectNumeral(word, p.entry, "nomn", "masc", ""); ok {
+				lemmas = append(lemmas, l)
+			}
+		}
+	}
+	for _, p := range ps {
+		var l string
+		var ok bool
+		switch tagPOS(p.tag) {
+		case "ADJF":
+			l, ok = a.inflectEntry(word, p.entry, "nomn", "sing", "masc", "")
+		case "NOUN":
+			l, ok = a.inflectEntry(word, p.entry, "nomn", "sing", "", "")
+		}
+		if ok {
+			lemmas = append(lemmas, l)
+		}
+	}
+	return lemmas
+}
+
+// fusedPrefixValue reads the first half of a fused ordinal back, the
+// inverse of [Analyzer.ordinalPrefix]: "двух" → 2, "двадцатиодно" → 21
+// The empty prefix of "тысячный" is one
+func (a *Analyzer) fusedPrefixValue(prefix string) (uint64, bool) {
+	if prefix == "" {
+		return 1, true
+	}
+	var value uint64
+	for prefix != "" {
+		best, bestLen := uint64(0), 0
+		for t := 1; t < 1000; t = nextComponent(t) {
+			p, ok := a.ordinalPrefix(t)
+			if t == 1 {
+				p, ok = "одно", true
+			}
+			p = strings.ReplaceAll(p, "ё", "е")
+			if ok && len(p) > bestLen && strings.HasPrefix(prefix, p) && fitsComponent(value, uint64(t), numberComponentWord) {
+				best, bestLen = uint64(t), len(p)
+			}
+		}
+		if bestLen == 0 {
+			return 0, false
+		}
+		value += best
+		prefix = prefix[bestLen:]
+	}
+	return value, true
+}
+
+// nextComponent steps through the values named by a single cardinal word:
+// 1–19, then the tens, then the hundreds
+func nextComponent(t int) int {
+	switch {
+	case t < 19:
+		return t + 1
+	case t < 90:
+		return t/10*10 + 10
+	case t == 90:
+		return 100
+	}
+	return t + 100
+}
+
+// fitsComponent reports whether a component of value v can follow the
+// components already summed in current, e.g. "двадцать" after "сто" but
+// not after "пять". Digit strings stand for a whole triple or more
+func fitsComponent(current, v uint64, kind int) bool {
+	if kind == numberDigitsWord || current == 0 {
+		return current == 0
+	}
+	r := current % 1000
+	switch {
+	case v >= 100:
+		return false
+	case v >= 10:
+		return r%100 == 0
+	default:
+		return r%10 == 0 && (r%100 == 0 || r%100 >= 20)
+	}
+}
+
+// pow1000 returns 1000 raised to k
+func pow1000(k int) uint64 {
+	v := uint64(1)
+	for ; k > 0; k-- {
+		v *= 1000
+	}
+	return v
+}
+
+// numberValues maps the lemmas of the unit, teen, ten and hundred
+// components to their values
+func numberValues(units, teens, tens, hundreds []string) map[string]uint64 {
+	m := make(map[string]uint64)
+	add := func(lemma string, v int) {
+		if lemma != "" {
+			m[strings.ReplaceAll(lemma, "ё", "е")] = uint64(v)
+		}
+	}
+	for i := 0; i < 10; i++ {
+		add(units[i], i)
+		add(teens[i], 10+i)
+		add(tens[i], 10*i)
+		add(hundreds[i], 100*i)
+	}
+	return m
+}
@@ -0,0 +1,299 @@
+package gomorphy
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestSpellNumber(t *testing.T) {
+	a := testAnalyzer
+
+	tests := []struct {
+		n                    int64
+		cas, gender, animacy string
+		want                 string
+	}{
+		{0, "nomn", "masc", "", "ноль"},
+		{0, "gent", "masc", "", "нуля"},
+		{1, "nomn", "masc", "", "один"},
+		{1, "nomn", "femn", "", "одна"},
+		{1, "nomn", "neut", "", "одно"},
+		{2, "nomn", "femn", "", "две"},
+		{21, "nomn", "femn", "", "двадцать одна"},
+		{21, "gent", "femn", "", "двадцати одной"},
+		{245, "gent", "masc", "", "двухсот сорока пяти"},
+		{245, "ablt", "masc", "", "двумястами сорока пятью"},
+		{1250, "nomn", "masc", "", "одна тысяча двести пятьдесят"},
+		{2000, "nomn", "masc", "", "две тысячи"},
+		{5000, "nomn", "masc", "", "пять тысяч"},
+		{5000, "datv", "masc", "", "пяти тысячам"},
+		{1000, "accs", "masc", "", "одну тысячу"},
+		{3000000, "nomn", "masc", "", "три миллиона"},
+		{11000000, "gent", "masc", "", "одиннадцати миллионов"},
+		{2000000000, "nomn", "masc", "", "два миллиарда"},
+		{-7, "nomn", "masc", "", "минус семь"},
+		// Animacy only shows in the accusative of "один" and a bare 2–4
+		{1, "accs", "masc", "anim", "одного"},
+		{1, "accs", "masc", "inan", "один"},
+		{2, "accs", "masc", "anim", "двух"},
+		{2, "accs", "masc", "inan", "два"},
+		{22, "accs", "masc", "anim", "двадцать два"},
+		{21, "accs", "masc", "anim", "двадцать одного"},
+	}
+
+	for _, tt := range tests {
+		got, ok := a.SpellNumber(tt.n, tt.cas, tt.gender, tt.animacy)
+		if !ok || got != tt.want {
+			t.Errorf("SpellNumber(%d, %q, %q, %q) = %q, %v; want %q", tt.n, tt.cas, tt.gender, tt.animacy, got, ok, tt.want)
+		}
+	}
+
+	if _, ok := a.SpellNumber(5, "voct", "masc", ""); ok {
+		t.Error("SpellNumber with an unsupported case should fail")
+	}
+}
+
+func TestSpellOrdinal(t *testing.T) {
+	a := testAnalyzer
+
+	tests := []struct {
+		n                            int64
+		cas, number, gender, animacy string
+		want                         string
+	}{
+		{0, "nomn", "sing", "masc", "", "нулевой"},
+		{1, "nomn", "sing", "masc", "", "первый"},
+		{3, "nomn", "sing", "femn", "", "третья"},
+		{16, "gent", "sing", "masc", "", "шестнадцатого"},
+		{40, "nomn", "sing", "masc", "", "сороковой"},
+		{100, "loct", "sing", "masc", "", "сотом"},
+		{2026, "nomn", "sing", "masc", "", "две тысячи двадцать шестой"},
+		{1985, "loct", "sing", "masc", "", "тысяча девятьсот восемьдесят пятом"},
+		{1000, "nomn", "sing", "masc", "", "тысячный"},
+		{2000, "gent", "sing", "masc", "", "двухтысячного"},
+		{21000, "nomn", "sing", "masc", "", "двадцатиоднотысячный"},
+		{100000, "nomn", "sing", "masc", "", "стотысячный"},
+		{1000001, "nomn", "sing", "masc", "", "миллион первый"},
+		{2, "nomn", "plur", "", "", "вторые"},
+		{1, "accs", "sing", "masc", "anim", "первого"},
+		{1, "accs", "sing", "masc", "", "первый"},
+	}
+
+	for _, tt := range tests {
+		got, ok := a.SpellOrdinal(tt.n, tt.cas, tt.number, tt.gender, tt.animacy)
+		if !ok || got != tt.want {
+			t.Errorf("SpellOrdinal(%d, %q, %q, %q, %q) = %q, %v; want %q", tt.n, tt.cas, tt.number, tt.gender, tt.animacy, got, ok, tt.want)
+		}
+	}
+
+	if _, ok := a.SpellOrdinal(-1, "nomn", "sing", "masc", ""); ok {
+		t.Error("SpellOrdinal of a negative number should fail")
+	}
+}
+
+func TestSpellFraction(t *testing.T) {
+	a := testAnalyzer
+
+	tests := []struct {
+		num, den int64
+		cas      string
+		want     string
+	}{
+		{1, 2, "nomn", "одна вторая"},
+		{1, 2, "gent", "одной второй"},
+		{3, 5, "nomn", "три пятых"},
+		{3, 5, "datv", "трём пятым"},
+		{2, 3, "nomn", "две третьих"},
+		{2, 3, "accs", "две третьих"},
+		{22, 7, "gent", "двадцати двух седьмых"},
+		{5, 8, "ablt", "пятью восьмыми"},
+		{21, 100, "nomn", "двадцать одна сотая"},
+	}
+
+	for _, tt := range tests {
+		got, ok := a.SpellFraction(tt.num, tt.den, tt.cas)
+		if !ok || got != tt.want {
+			t.Errorf("SpellFraction(%d, %d, %q) = %q, %v; want %q", tt.num, tt.den, tt.cas, got, ok, tt.want)
+		}
+	}
+
+	if _, ok := a.SpellFraction(1, 0, "nomn"); ok {
+		t.Error("SpellFraction with a zero denominator should fail")
+	}
+}
+
+func TestSplitTriples(t *testing.T) {
+	tests := []struct {
+		u    uint64
+		want []int
+	}{
+		{7, []int{7}},
+		{1250, []int{250, 1}},
+		{2000000, []int{0, 0, 2}},
+	}
+
+	for _, tt := range tests {
+		if got := splitTriples(tt.u); !slices.Equal(got, tt.want) {
+			t.Errorf("splitTriples(%d) = %v, want %v", tt.u, got, tt.want)
+		}
+	}
+}
+
+func TestParseNumber(t *testing.T) {
+	a := testAnalyzer
+
+	tests := []struct {
+		text    string
+		want    int64
+		ordinal bool
+		span    string
+	}{
+		{"двумястами сорока пятью рублями", 245, false, "двумястами сорока пятью"},
+		{"оплата в размере двухсот рублей", 200, false, "двухсот"},
+		{"сто двадцать третьего числа", 123, true, "сто двадцать третьего"},
+		{"около 3 тысяч человек", 3000, false, "3 тысяч"},
+		{"две тысячи двадцать шестой год", 2026, true, "две тысячи двадцать шестой"},
+		{"одна тысяча двести пятьдесят", 1250, false, "одна тысяча двести пятьдесят"},
+		{"пять миллионов триста тысяч", 5300000, false, "пять миллионов триста тысяч"},
+		{"в двухтысячном году", 2000, true, "двухтысячном"},
+		{"тысяча", 1000, false, "тысяча"},
+		{"минус семь градусов", -7, false, "минус семь"},
+		{"ноль", 0, false, "ноль"},
+		{"счёт 42", 42, false, "42"},
+		// A number stops at punctuation and out-of-order components
+		{"пять, шесть", 5, false, "пять"},
+		{"три двадцать", 3, false, "три"},
+		// and before a scale that would overflow the value
+		{"99999999999 квинтиллионов", 99999999999, false, "99999999999"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			got, ok := a.ParseNumber(tt.text)
+			if !ok {
+				t.Fatalf("ParseNumber(%q) found nothing", tt.text)
+			}
+			if got.Value != tt.want || got.Ordinal != tt.ordinal || tt.text[got.Start:got.End] != tt.span {
+				t.Errorf("ParseNumber(%q) = %d, ordinal %v, span %q; want %d, %v, %q",
+					tt.text, got.Value, got.Ordinal, tt.text[got.Start:got.End], tt.want, tt.ordinal, tt.span)
+			}
+		})
+	}
+
+	if _, ok := a.ParseNumber("нет здесь чисел"); ok {
+		t.Error("ParseNumber should find nothing in text without numbers")
+	}
+}
+
+func TestFindNumbers(t *testing.T) {
+	a := testAnalyzer
+
+	got := a.FindNumbers("с третьего по десятое марта, двести пятьдесят участников")
+	want := []int64{3, 10, 250}
+	if len(got) != len(want) {
+		t.Fatalf("FindNumbers found %d numbers, want %d: %+v", len(got), len(want), got)
+	}
+	for i, s := range got {
+		if s.Value != want[i] {
+			t.Errorf("FindNumbers()[%d] = %d, want %d", i, s.Value, want[i])
+		}
+	}
+}
+
+func TestFitsComponent(t *testing.T) {
+	tests := []struct {
+		current, v uint64
+		kind       int
+		want       bool
+	}{
+		{0, 100, numberComponentWord, true},
+		{100, 20, numberComponentWord, true},
+		{120, 3, numberComponentWord, true},
+		{100, 13, numberComponentWord, true},
+		{113, 3, numberComponentWord, false},
+		{3, 20, numberComponentWord, false},
+		{20, 30, numberComponentWord, false},
+		{0, 42, numberDigitsWord, true},
+		{5, 42, numberDigitsWord, false},
+	}
+
+	for _, tt := range tests {
+		if got := fitsComponent(tt.current, tt.v, tt.kind); got != tt.want {
+			t.Errorf("fitsComponent(%d, %d) = %v, want %v", tt.current, tt.v, got, tt.want)
+		}
+	}
+}
+
+func TestAbbrOrdinal(t *testing.T) {
+	a := testAnalyzer
+
+	tests := []struct {
+		n                            int64
+		cas, number, gender, animacy string
+		want                         string
+	}{
+		{1, "nomn", "sing", "masc", "", "1-й"},
+		{2, "gent", "sing", "masc", "", "2-го"},
+		{3, "loct", "sing", "masc", "", "3-м"},
+		{3, "nomn", "sing", "femn", "", "3-я"},
+		{5, "nomn", "sing", "femn", "", "5-я"},
+		{5, "accs", "sing", "femn", "", "5-ю"},
+		{10, "datv", "sing", "neut", "", "10-му"},
+		{2, "nomn", "plur", "", "", "2-е"},
+		{90, "gent", "plur", "", "", "90-х"},
+		{7, "ablt", "plur", "", "", "7-ми"},
+	}
+
+	for _, tt := range tests {
+		got, ok := a.AbbrOrdinal(tt.n, tt.cas, tt.number, tt.gender, tt.animacy)
+		if !ok || got != tt.want {
+			t.Errorf("AbbrOrdinal(%d, %q, %q, %q, %q) = %q, %v; want %q", tt.n, tt.cas, tt.number, tt.gender, tt.animacy, got, ok, tt.want)
+		}
+	}
+}
+
+func TestAbbrOrdinalFor(t *testing.T) {
+	a := testAnalyzer
+
+	tests := []struct {
+		n      int64
+		phrase string
+		want   string
+	}{
+		{3, "классе", "3-м"},
+		{5, "улица", "5-я"},
+		{2, "этажа", "2-го"},
+		{1, "мая", "1-го"},
+		{4, "новой школе", "4-й"},
+		{21, "веке", "21-м"},
+	}
+
+	for _, tt := range tests {
+		got, ok := a.AbbrOrdinalFor(tt.n, tt.phrase)
+		if !ok || got != tt.want {
+			t.Errorf("AbbrOrdinalFor(%d, %q) = %q, %v; want %q", tt.n, tt.phrase, got, ok, tt.want)
+		}
+	}
+
+	if _, ok := a.AbbrOrdinalFor(1, "быстро"); ok {
+		t.Error("AbbrOrdinalFor without a noun should fail")
+	}
+}
+
+func TestOrdinalEnding(t *testing.T) {
+	tests := map[string]string{
+		"первый":  "й",
+		"второго": "го",
+		"третья":  "я",
+		"третьем": "м",
+		"пятыми":  "ми",
+		"пятому":  "му",
+		"пятых":   "х",
+	}
+
+	for spelled, want := range tests {
+		if got := ordinalEnding(spelled); got != want {
+			t.Errorf("ordinalEnding(%q) = %q, want %q", spelled, got, want)
+		}
+	}
+}
@@ -0,0 +1,165 @@
+package gomorphy
+
+// numeralGroup is a cardinal numeral counting the head of a phrase,
+// either a run of NUMR words ("двадцать один") or a single digit token ("21")
+type numeralGroup struct {
+	words    []int  // indices of the numeral tokens in the phrase
+	digits   bool   // the numeral is written with digits and never changes
+	category string // plural category of the last component: one, few or many
+	compound bool   // more than one component, e.g. "двадцать два" or "22"
+}
+
+// numeralPlan describes how a counted noun group is declined to one case
+type numeralPlan struct {
+	numCase, numAnimacy  string // form of the numeral itself
+	nounCase, nounNumber string // form of the counted noun
+	adjCase, adjNumber   string // form of its adjectives, before accusative agreement
+}
+
+// fewLemmas are the numerals that take the genitive singular of the noun
+// in the nominative: "два стола", "три кошки", "полтора часа"
+var fewLemmas = map[string]bool{
+	"два": true, "три": true, "четыре": true, "оба": true, "полтора": true,
+}
+
+// number returns the grammatical number the counted group is declined in
+func (g *numeralGroup) number() string {
+	if g.category == "one" {
+		return "sing"
+	}
+	return "plur"
+}
+
+// contains reports whether the token at index i belongs to the numeral
+func (g *numeralGroup) contains(i int) bool {
+	for _, j := range g.words {
+		if j == i {
+			return true
+		}
+	}
+	return false
+}
+
+// plan applies the numeral government rules for cas to a counted noun of the
+// given gender and animacy:
+//   - "один" agrees with the noun like an adjective in every case
+//   - in the nominative and inanimate accusative, 2–4 take the genitive
+//     singular of the noun ("два стола") and 5+ the genitive plural ("пять
+//     столов"); adjectives go to the genitive plural, except after 2–4 with
+//     feminine nouns where the nominative plural is used ("две красивые кошки")
+//   - the animate accusative of a simple 2–4 follows the genitive ("двух
+//     кошек"), while 5+ and compound numerals keep the inanimate form
+//   - in the oblique cases the numeral, noun and adjectives all take the case,
+//     with the noun group in the plural
+func (g *numeralGroup) plan(cas, gender, animacy string) numeralPlan {
+	c := baseCase(cas)
+	if g.category == "one" {
+		return numeralPlan{c, animacy, c, "sing", c, "sing"}
+	}
+	if c == "nomn" || c == "accs" && (g.category == "many" || g.compound || animacy != "anim") {
+		pl := numeralPlan{numCase: c, nounCase: "gent", nounNumber: "plur", adjCase: "gent", adjNumber: "plur"}
+		if c == "accs" {
+			pl.numAnimacy = "inan"
+		}
+		if g.category == "few" {
+			pl.nounNumber = "sing"
+			if gender == "femn" {
+				pl.adjCase = "nomn"
+			}
+		}
+		return pl
+	}
+	return numeralPlan{c, animacy, c, "plur", c, "plur"}
+}
+
+// counts reports whether a noun parsed as tag is in a form g governs in some
+// case: "5 лет" and "2 стола" are counts, "2026 год" and "5 мая" are not
+func (g *numeralGroup) counts(tag, gender, animacy string) bool {
+	cas := baseCase(tagGrammeme(tag, caseGrammemes))
+	number := tagGrammeme(tag, numberGrammemes)
+	for _, c := range phraseCases {
+		pl := g.plan(c, gender, animacy)
+		if pl.nounCase == cas && pl.nounNumber == number {
+			return true
+		}
+	}
+	return false
+}
+
+// numeralGroupAt builds the numeral group from the tokens at indices,
+// which must be NUMR words or a single digit token
+func (a *Analyzer) numeralGroupAt(p phraseAnalysis, indices []int) *numeralGroup {
+	g := &numeralGroup{words: indices, compound: len(indices) > 1}
+	last := p.words[indices[len(indices)-1]]
+	if isDigits(last.text) {
+		g.digits = true
+		g.compound = g.compound || len(last.text) > 1
+		g.category = digitsCategory(last.text)
+		return g
+	}
+
+	lemma, _ := a.inflectNumeral(last.text, last.parse.entry, "nomn", "masc", "")
+	switch {
+	case lemma == "один":
+		g.category = "one"
+	case fewLemmas[lemma]:
+		g.category = "few"
+	default:
+		g.category = "many"
+	}
+	return g
+}
+
+// inflectNumeral declines a numeral within its lexeme. Numerals mark gender
+// and animacy only on some forms ("одна", "двух" vs "два"), so the match is
+// relaxed step by step when the exact combination does not exist
+func (a *Analyzer) inflectNumeral(word string, e wordEntry, cas, gender, animacy string) (string, bool) {
+	for _, ga := range [][2]string{{gender, animacy}, {gender, ""}, {"", animacy}, {"", ""}} {
+		if f, ok := a.inflectEntry(word, e, cas, "", ga[0], ga[1]); ok {
+			return f, true
+		}
+	}
+	return "", false
+}
+
+// numeralParse returns the NUMR parse of a word, if it has one
+// Numerals such as "сорок" or "три" are also nouns or verbs, so inside a
+// phrase the numeral reading is preferred over POS priority when a noun
+// follows it
+func (a *Analyzer) numeralParse(entries []wordEntry) (parse, bool) {
+	for _, e := range entries {
+		if t := a.entryTag(e); tagPOS(t) == "NUMR" {
+			return parse{entry: e, tag: t}, true
+		}
+	}
+	return parse{}, false
+}
+
+// digitsCategory returns the plural category of a number written in digits:
+// one for 1, 21, 101…, few for 2–4, 22–24…, many otherwise (including 11–14)
+func digitsCategory(digits string) string {
+	n := int(digits[len(digits)-1] - '0')
+	if len(digits) > 1 && digits[len(digits)-2] == '1' {
+		return "many"
+	}
+	switch {
+	case n == 1:
+		return "one"
+	case n >= 2 && n <= 4:
+		return "few"
+	}
+	return "many"
+}
+
+// isDigits reports whether s is a non-empty string of ASCII digits
+func isDigits(s string) bool {
+	if s == "" {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
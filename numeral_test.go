@@ -0,0 +1,108 @@
+package gomorphy
+
+import "testing"
+
+func TestInflectPhrase_Numerals(t *testing.T) {
+	a := testAnalyzer
+
+	tests := []struct {
+		phrase, cas, number string
+		want                string
+	}{
+		{"две красивые кошки", "nomn", "plur", "две красивые кошки"},
+		{"две красивые кошки", "gent", "plur", "двух красивых кошек"},
+		{"две красивые кошки", "accs", "plur", "двух красивых кошек"},
+		{"две красивые кошки", "ablt", "plur", "двумя красивыми кошками"},
+		{"два больших стола", "accs", "plur", "два больших стола"},
+		{"два больших стола", "datv", "plur", "двум большим столам"},
+		{"пять новых файлов", "nomn", "plur", "пять новых файлов"},
+		{"пять новых файлов", "datv", "plur", "пяти новым файлам"},
+		{"пять новых файлов", "loct", "plur", "пяти новых файлах"},
+		{"пять долгих лет", "nomn", "plur", "пять долгих лет"},
+		{"двадцать один день", "nomn", "sing", "двадцать один день"},
+		{"двадцать один день", "gent", "sing", "двадцати одного дня"},
+		{"одна новая задача", "accs", "sing", "одну новую задачу"},
+		// Digits never change, only the counted group does
+		{"5 новых файлов", "ablt", "plur", "5 новыми файлами"},
+		{"21 день", "datv", "sing", "21 дню"},
+		{"3 кошки", "gent", "plur", "3 кошек"},
+		// Digits the head is not counted by are labels, e.g. years and days
+		{"2026 год", "gent", "sing", "2026 года"},
+		{"в 2026 году", "loct", "sing", "в 2026 году"},
+		{"5 мая", "gent", "sing", "5 мая"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.phrase+"/"+tt.cas, func(t *testing.T) {
+			got, ok := a.InflectPhrase(tt.phrase, tt.cas, tt.number)
+			if !ok || got != tt.want {
+				t.Errorf("InflectPhrase(%q, %q, %q) = %q, %v; want %q", tt.phrase, tt.cas, tt.number, got, ok, tt.want)
+			}
+		})
+	}
+
+	// The numeral dictates the number of the group
+	if _, ok := a.InflectPhrase("пять новых файлов", "nomn", "sing"); ok {
+		t.Error("InflectPhrase(пять новых файлов, nomn, sing) reported success")
+	}
+}
+
+func TestNormalizePhrase_Numerals(t *testing.T) {
+	a := testAnalyzer
+
+	if got, ok := a.NormalizePhrase("двух красивых кошек"); !ok || got != "две красивые кошки" {
+		t.Errorf("NormalizePhrase(двух красивых кошек) = %q, %v; want %q", got, ok, "две красивые кошки")
+	}
+}
+
+func TestDigitsCategory(t *testing.T) {
+	tests := []struct {
+		digits string
+		want   string
+	}{
+		{"1", "one"}, {"21", "one"}, {"101", "one"},
+		{"2", "few"}, {"4", "few"}, {"23", "few"},
+		{"5", "many"}, {"0", "many"}, {"11", "many"}, {"12", "many"}, {"114", "many"}, {"100", "many"},
+	}
+	for _, tt := range tests {
+		if got := digitsCategory(tt.digits); got != tt.want {
+			t.Errorf("digitsCategory(%q) = %q, want %q", tt.digits, got, tt.want)
+		}
+	}
+}
+
+func TestCountPhrase(t *testing.T) {
+	a := testAnalyzer
+
+	tests := []struct {
+		n           int64
+		phrase, cas string
+		want        string
+	}{
+		{1, "новый файл", "nomn", "новый файл"},
+		{2, "новый файл", "nomn", "новых файла"},
+		{5, "новый файл", "nomn", "новых файлов"},
+		{5, "новый файл", "datv", "новым файлам"},
+		{3, "минута", "nomn", "минуты"},
+		{2, "минута", "gent", "минут"},
+		{21, "минута", "accs", "минуту"},
+		{12, "красивая кошка", "nomn", "красивых кошек"},
+		{3, "красивые кошки", "nomn", "красивые кошки"},
+		// Suppletive genitive plurals after numerals
+		{5, "год", "nomn", "лет"},
+		{25, "долгий год", "gent", "долгих лет"},
+		{5, "год", "datv", "годам"},
+		{7, "человек", "nomn", "человек"},
+	}
+
+	for _, tt := range tests {
+		got, ok := a.CountPhrase(tt.n, tt.phrase, tt.cas)
+		if !ok || got != tt.want {
+			t.Errorf("CountPhrase(%d, %q, %q) = %q, %v; want %q", tt.n, tt.phrase, tt.cas, got, ok, tt.want)
+		}
+	}
+
+	if _, ok := a.CountPhrase(5, "пять файлов", "nomn"); ok {
+		t.Error("CountPhrase of a phrase with a numeral should fail")
+	}
+}
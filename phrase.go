@@ -0,0 +1,823 @@
+package gomorphy
+
+import (
+	"slices"
+	"sort"
+	"strings"
+)
+
+// phraseCases and phraseNumbers are the case × number grid walked by the phrase APIs
+var (
+	phraseCases   = []string{"nomn", "gent", "datv", "accs", "ablt", "loct"}
+	phraseNumbers = []string{"sing", "plur"}
+)
+
+// Grammeme sets used to pull a single category out of a tag
+// caseGrammemes also gives the canonical case order of a [PhraseTable]
+var (
+	caseGrammemes    = []string{"nomn", "gent", "gen2", "datv", "accs", "ablt", "loct", "loc2", "voct"}
+	numberGrammemes  = []string{"sing", "plur"}
+	genderGrammemes  = []string{"masc", "femn", "neut"}
+	animacyGrammemes = []string{"anim", "inan"}
+)
+
+// Cell identifies a single slot of a declension table
+type Cell struct {
+	Case   string // OpenCorpora case grammeme, e.g. "datv" or "loc2"
+	Number string // "sing" or "plur"
+}
+
+// PhraseTable is the declension table of a phrase
+// Unlike [Analyzer.PhraseFormsConcordant] every form is addressed by its
+// grammatical slot, so callers never depend on the position of a string
+type PhraseTable struct {
+	// Original is the input phrase, lower-cased and trimmed
+	Original string
+	// Forms maps each case × number slot to the declined phrase
+	// Slots the phrase cannot be declined into are absent
+	Forms map[Cell]string
+}
+
+// Missing returns the slots of the main case × number grid the phrase has
+// no form for, in the canonical order of [PhraseTable.Cells], e.g. the
+// singular of "ножницы", the plural of "молоко" or the cases a leading
+// preposition does not govern
+func (t *PhraseTable) Missing() []Cell {
+	if t == nil {
+		return nil
+	}
+	var cells []Cell
+	for _, number := range phraseNumbers {
+		for _, cas := range phraseCases {
+			c := Cell{Case: cas, Number: number}
+			if _, ok := t.Forms[c]; !ok {
+				cells = append(cells, c)
+			}
+		}
+	}
+	return cells
+}
+
+// Get returns the phrase declined to the given case and number,
+// or an empty string if the table has no such slot
+func (t *PhraseTable) Get(cas, number string) string {
+	if t == nil {
+		return ""
+	}
+	return t.Forms[Cell{Case: cas, Number: number}]
+}
+
+// Cells returns the slots present in the table in canonical order:
+// singular before plural, cases as listed in nomn, gent, gen2, datv, accs, ablt,
+// loct, loc2, voct
+func (t *PhraseTable) Cells() []Cell {
+	if t == nil {
+		return nil
+	}
+	var cells []Cell
+	for _, number := range phraseNumbers {
+		for _, cas := range caseGrammemes {
+			c := Cell{Case: cas, Number: number}
+			if _, ok := t.Forms[c]; ok {
+				cells = append(cells, c)
+			}
+		}
+	}
+	return cells
+}
+
+// PhraseFormsTable declines a Russian phrase like [Analyzer.PhraseFormsConcordant]
+// but returns the forms keyed by case and number
+//
+// The second genitive (gen2), second locative (loc2) and vocative (voct)
+// are included only when the head noun has them, e.g. "чаю", "в лесу" or
+// "Маш"; agreeing adjectives take the genitive, locative and nominative there
+//
+// A noun counted by a numeral ("две кошки", "5 файлов") follows the
+// numeral's government, and a phrase opening with a preposition is only
+// declined into the cases it governs (see [PrepositionCases]), taking loc2
+// after "в" and "на" ("в саду"). Plural-only and singular-only heads
+// ("ножницы", "молоко") keep their own number, and indeclinable nouns
+// ("пальто") keep their form. Slots some word has no form for are left out,
+// see [PhraseTable.Missing]
+// Returns nil for an empty phrase. A phrase without a noun yields a table
+// with no forms
+func (a *Analyzer) PhraseFormsTable(phrase string) *PhraseTable {
+	phrase = strings.ToLower(strings.TrimSpace(phrase))
+	words := strings.Fields(phrase)
+	if len(words) == 0 {
+		return nil
+	}
+
+	t := &PhraseTable{Original: phrase, Forms: make(map[Cell]string)}
+	p := a.analyzePhrase(words)
+	if p.head == -1 {
+		return t
+	}
+
+	head := p.words[p.head]
+	for _, number := range phraseNumbers {
+		for _, cas := range caseGrammemes {
+			if !p.allows(cas) || !p.allowsNumber(number) || cas != baseCase(cas) && !a.hasForm(head.parse.entry, cas, number) {
+				continue
+			}
+			if form, ok := a.declinePhrase(p, cas, number); ok {
+				t.Forms[Cell{Case: cas, Number: number}] = form
+			}
+		}
+	}
+	return t
+}
+
+// InflectPhrase declines a Russian phrase to a single case and number,
+// e.g. InflectPhrase("красивая кошка", "datv", "plur") → "красивым кошкам"
+//
+// The head noun is chosen and its modifiers agreed exactly as in
+// [Analyzer.PhraseFormsConcordant]. cas is an OpenCorpora case grammeme
+// (nomn, gent, gen2, datv, accs, ablt, loct, loc2, voct), number is "sing"
+// or "plur". gen2, loc2 and voct fall back to gent, loct and nomn for nouns
+// that lack them, and after a preposition governing the second locative the
+// locative is produced as loc2 where the noun has it:
+// InflectPhrase("в сад", "loct", "sing") → "в саду"
+// Reports false if cas or number is not one of these grammemes, the phrase
+// has no noun or any agreeing word cannot be inflected to the requested form
+func (a *Analyzer) InflectPhrase(phrase, cas, number string) (string, bool) {
+	if !slices.Contains(caseGrammemes, cas) || !slices.Contains(numberGrammemes, number) {
+		return "", false
+	}
+	words := strings.Fields(strings.ToLower(strings.TrimSpace(phrase)))
+	if len(words) == 0 {
+		return "", false
+	}
+	p := a.analyzePhrase(words)
+	if p.head == -1 {
+		return "", false
+	}
+	return a.declinePhrase(p, cas, number)
+}
+
+// NormalizePhrase returns the dictionary form of an inflected noun phrase,
+// e.g. "красивой кошке" → "красивая кошка", "новых пользователей" → "новый пользователь"
+//
+// The case of the head noun is detected from its agreement with the
+// adjectives and participles of the phrase, and every word is put back to
+// the nominative from the parse consistent with the rest of the group
+// The result is singular unless the head has no singular forms,
+// e.g. "острых ножниц" → "острые ножницы"
+// Reports false if the phrase has no noun or any agreeing word cannot be
+// inflected
+func (a *Analyzer) NormalizePhrase(phrase string) (string, bool) {
+	words := strings.Fields(strings.ToLower(strings.TrimSpace(phrase)))
+	if len(words) == 0 {
+		return "", false
+	}
+	p := a.analyzePhrase(words)
+	if p.head == -1 {
+		return "", false
+	}
+
+	number := "sing"
+	switch {
+	case p.numeral != nil:
+		number = p.numeral.number()
+	case !a.hasForm(p.words[p.head].parse.entry, "nomn", "sing"):
+		number = "plur"
+	}
+	return a.declinePhrase(p, "nomn", number)
+}
+
+// PhraseReadings returns the case × number readings of an inflected phrase
+// that are consistent across its agreeing words, e.g.
+// "красивой кошке" → [datv sing, loct sing] and
+// "красивые столы" → [nomn plur, accs plur]
+//
+// Every parse of the head noun is intersected with the parses of the
+// adjectives and participles agreeing with it, so a reading survives only
+// if all of them can express it. Readings are returned in the canonical
+// order of [PhraseTable.Cells]; nil means the phrase has no noun or its
+// words do not agree
+func (a *Analyzer) PhraseReadings(phrase string) []Cell {
+	words := strings.Fields(strings.ToLower(strings.TrimSpace(phrase)))
+	if len(words) == 0 {
+		return nil
+	}
+	p := a.analyzePhrase(words)
+	if p.head == -1 {
+		return nil
+	}
+
+	found := make(map[Cell]struct{})
+	for _, r := range a.agreementReadings(p) {
+		tag := r[p.head].tag
+		found[Cell{
+			Case:   tagGrammeme(tag, caseGrammemes),
+			Number: tagGrammeme(tag, numberGrammemes),
+		}] = struct{}{}
+	}
+
+	var cells []Cell
+	for _, number := range phraseNumbers {
+		for _, cas := range caseGrammemes {
+			if _, ok := found[Cell{Case: cas, Number: number}]; ok && p.allows(cas) {
+				cells = append(cells, Cell{Case: cas, Number: number})
+			}
+		}
+	}
+	return cells
+}
+
+// WordParse is the analysis chosen for one word of a phrase
+type WordParse struct {
+	Word string // the word as it appears in the phrase, lower-cased
+	Tag  string // OpenCorpora tag of the chosen parse; empty for service and unknown words
+}
+
+// PhraseParses returns the parse chosen for every word of a phrase, the same
+// parses the phrase APIs decline from
+//
+// Ambiguous words are resolved jointly by agreement rather than by POS
+// priority alone: in "простой вопрос" the first word is read as an adjective
+// agreeing with "вопрос", not as the noun "простой". The head and the
+// adjectives agreeing with it carry the tags of a consistent case and number
+// Returns nil for an empty phrase
+func (a *Analyzer) PhraseParses(phrase string) []WordParse {
+	words := strings.Fields(strings.ToLower(strings.TrimSpace(phrase)))
+	if len(words) == 0 {
+		return nil
+	}
+	p := a.analyzePhrase(words)
+	result := make([]WordParse, len(p.words))
+	for i, w := range p.words {
+		result[i] = WordParse{Word: w.text, Tag: w.parse.tag}
+	}
+	return result
+}
+
+// phraseWord is the analysis of a single word of a phrase
+// pos is empty for service words and words missing from the dictionary
+// fixed words belong to a dependent group and keep their form
+// attach is the index of the noun an adjective or participle agrees with, -1 otherwise
+type phraseWord struct {
+	text    string
+	parse   parse
+	pos     string
+	animacy string
+	gender  string
+	fixed   bool
+	attach  int
+}
+
+// phraseAnalysis is a phrase split into analysed words
+// head is the index of the grammatical head, or -1 if the phrase has no noun
+// governed lists the cases allowed by a preposition opening the phrase,
+// nil means the phrase can take any case
+// numeral is the numeral counting the head, if any
+type phraseAnalysis struct {
+	words    []phraseWord
+	head     int
+	governed []string
+	numeral  *numeralGroup
+}
+
+// allows reports whether the phrase can be declined to cas
+func (p phraseAnalysis) allows(cas string) bool {
+	return p.governed == nil || slices.Contains(p.governed, cas)
+}
+
+// allowsNumber reports whether the phrase can be declined to number;
+// a counted group only exists in the number its numeral dictates, and a
+// plural-only (Pltm) or singular-only (Sgtm) head only in its own number
+func (p phraseAnalysis) allowsNumber(number string) bool {
+	if p.numeral != nil {
+		return p.numeral.number() == number
+	}
+	if p.head >= 0 {
+		tag := p.words[p.head].parse.tag
+		if number == "sing" && strings.Contains(tag, "Pltm") || number == "plur" && strings.Contains(tag, "Sgtm") {
+			return false
+		}
+	}
+	return true
+}
+
+// maxAmbiguousWords caps the number of words whose noun/adjective reading is
+// disambiguated jointly, keeping the search at 2^maxAmbiguousWords structures
+const maxAmbiguousWords = 8
+
+// analyzePhrase chooses a parse for every word and builds the dependency
+// structure of the head group
+//
+// Parses are not picked word by word: every word that can be read both as a
+// noun and as an adjective or participle ("простой", "столовая") is tried
+// in both roles, and the combination under which the most modifiers agree
+// with a noun wins. Ties keep the POS-priority reading. A numeral reading is
+// preferred only when a noun follows for it to count. The head and its
+// modifiers then take the parses of the first reading in which they agree
+// in case and number, see [Analyzer.agreementReadings]
+func (a *Analyzer) analyzePhrase(words []string) phraseAnalysis {
+	candidates := make([][]parse, len(words))
+	choice := make([]parse, len(words))
+	var ambiguous []int
+	for i, w := range words {
+		if !serviceWords[w] {
+			candidates[i] = a.parses(w)
+		}
+	}
+	for i, w := range words {
+		if len(candidates[i]) == 0 {
+			continue
+		}
+		choice[i] = candidates[i][0]
+		if np, ok := a.numeralParse(a.words.get(w)); ok && nominalFollows(words, candidates, i) {
+			choice[i] = np
+			continue
+		}
+		if _, ok := alternateRole(candidates[i], choice[i]); ok && len(ambiguous) < maxAmbiguousWords {
+			ambiguous = append(ambiguous, i)
+		}
+	}
+
+	best, bestScore := a.structurePhrase(words, choice)
+	for mask := 1; mask < 1<<len(ambiguous); mask++ {
+		alt := make([]parse, len(choice))
+		copy(alt, choice)
+		for k, i := range ambiguous {
+			if mask&(1<<k) != 0 {
+				alt[i], _ = alternateRole(candidates[i], choice[i])
+			}
+		}
+		if p, score := a.structurePhrase(words, alt); score > bestScore {
+			best, bestScore = p, score
+		}
+	}
+
+	if best.head != -1 {
+		if readings := a.agreementReadings(best); len(readings) > 0 {
+			best = best.withReading(readings[0])
+		}
+	}
+	return best
+}
+
+// nominalFollows reports whether a word after i and before the next
+// preposition can be read as a noun, so that a numeral reading at i has
+// something to count: "сорока кошек" but not "белая сорока"
+func nominalFollows(words []string, candidates [][]parse, i int) bool {
+	for j := i + 1; j < len(words) && !isPreposition(words[j]); j++ {
+		for _, c := range candidates[j] {
+			if isNominal(tagPOS(c.tag)) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// alternateRole returns the first parse of candidates playing the other
+// role than current: a modifier for a noun reading and vice versa
+// Reports false if there is no such parse
+func alternateRole(candidates []parse, current parse) (parse, bool) {
+	nominal := isNominal(tagPOS(current.tag))
+	if !nominal && !isModifier(tagPOS(current.tag)) {
+		return parse{}, false
+	}
+	for _, c := range candidates {
+		pos := tagPOS(c.tag)
+		if nominal && isModifier(pos) || !nominal && isNominal(pos) {
+			return c, true
+		}
+	}
+	return parse{}, false
+}
+
+// structurePhrase builds the dependency structure of a phrase whose words
+// are analysed as choice, and scores it by the number of adjectives and
+// participles that agree with a noun
+//
+// The head group runs up to the first preposition inside the phrase; that
+// preposition and everything after it form a dependent group that keeps its
+// governed case. When the phrase itself opens with a preposition, the group
+// after it is the head group and only the cases the preposition governs
+// are allowed
+//
+// Inside the head group the first noun or pronoun is the head. Nouns after it
+// that can be read as genitive form a chain of dependents ("стол директора
+// школы") and stay fixed. Nouns joined by a coordinating conjunction
+// ("кошка и собака") and proper nouns in apposition ("город Москва")
+// decline in parallel with the noun before them; after a common noun a
+// proper noun that can be read as genitive is taken for a dependent
+// instead ("улица Иванова", "дом Пушкина"). Every
+// adjective or participle attaches to the nearest following noun it can
+// agree with, or else to the nearest preceding one, and shares that noun's fate
+//
+// Cardinal numerals (NUMR words or a number in digits) before the head count
+// it: the group is then declined by the numeral government rules, see
+// [numeralGroup.plan]. A number in digits counts the head only if the head
+// is in a form the numeral governs in some case; otherwise it stays as is
+func (a *Analyzer) structurePhrase(words []string, choice []parse) (phraseAnalysis, int) {
+	p := phraseAnalysis{words: make([]phraseWord, len(words)), head: -1}
+
+	start, end := 0, len(words)
+	if isPreposition(words[0]) {
+		p.governed = prepositionCases[words[0]]
+		start = 1
+	}
+	for i := start; i < len(words); i++ {
+		if isPreposition(words[i]) {
+			end = i
+			break
+		}
+	}
+
+	var nouns []int
+	for i, w := range words {
+		fixed := i < start || i >= end
+		p.words[i] = phraseWord{text: w, fixed: fixed, attach: -1}
+		if choice[i].tag == "" {
+			continue
+		}
+		pos := tagPOS(choice[i].tag)
+		p.words[i] = phraseWord{
+			text:    w,
+			parse:   choice[i],
+			pos:     pos,
+			animacy: tagGrammeme(choice[i].tag, animacyGrammemes),
+			gender:  tagGrammeme(choice[i].tag, genderGrammemes),
+			fixed:   fixed,
+			attach:  -1,
+		}
+		if !fixed && isNominal(pos) {
+			nouns = append(nouns, i)
+		}
+	}
+	if len(nouns) == 0 {
+		return p, 0
+	}
+
+	p.head = nouns[0]
+	for k, i := range nouns[1:] {
+		prev := nouns[k]
+		switch {
+		case hasCoordination(words[prev+1 : i]):
+			// Conjuncts decline in parallel; coordinated dependents stay fixed
+			p.words[i].fixed = p.words[prev].fixed
+		case isProperNoun(p.words[i].parse.tag) && isProperNoun(p.words[prev].parse.tag) &&
+			a.sharesCase(words[prev], words[i]):
+			// Parts of a name: "Анна Иванова"
+		case a.canBeGenitive(words[i]):
+			// A genitive reading wins after a common noun: "улица Иванова"
+			p.words[i].fixed = true
+		case isProperNoun(p.words[i].parse.tag) && a.sharesCase(words[prev], words[i]):
+			// Apposition: "город Москва", "река Волга"
+		}
+	}
+
+	var numerals []int
+	for i := start; i < p.head; i++ {
+		if p.words[i].pos == "NUMR" || isDigits(p.words[i].text) {
+			numerals = append(numerals, i)
+		}
+	}
+	if len(numerals) > 0 {
+		head := p.words[p.head]
+		// A number in digits may be a date or a label rather than a count:
+		// "2026 год", "5 мая"
+		if g := a.numeralGroupAt(p, numerals); !g.digits || g.counts(head.parse.tag, head.gender, head.animacy) {
+			p.numeral = g
+		}
+	}
+
+	score := 0
+	for i := start; i < end; i++ {
+		if !isModifier(p.words[i].pos) {
+			continue
+		}
+		target := p.head
+		if j := a.attachment(p, nouns, i); j != -1 {
+			target = j
+			score++
+		}
+		p.words[i].attach = target
+		p.words[i].fixed = p.words[target].fixed
+	}
+	return p, score
+}
+
+// attachment returns the noun the modifier at index i agrees with: the
+// nearest following noun if possible, otherwise the nearest preceding one
+// Returns -1 if the modifier agrees with neither
+func (a *Analyzer) attachment(p phraseAnalysis, nouns []int, i int) int {
+	prev, next := -1, -1
+	for _, j := range nouns {
+		if j < i {
+			prev = j
+		} else if next == -1 {
+			next = j
+		}
+	}
+	mod := p.words[i].text
+	if next != -1 && a.canAgree(p.words[next].text, mod) {
+		return next
+	}
+	if prev != -1 && a.canAgree(p.words[prev].text, mod) {
+		return prev
+	}
+	return -1
+}
+
+// canAgree reports whether any parse of mod agrees with any nominal parse of noun
+func (a *Analyzer) canAgree(noun, mod string) bool {
+	modParses := a.parses(mod)
+	for _, np := range a.parses(noun) {
+		if !isNominal(tagPOS(np.tag)) {
+			continue
+		}
+		for _, mp := range modParses {
+			if pos := tagPOS(mp.tag); (pos == "ADJF" || pos == "PRTF") && agrees(np.tag, mp.tag) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// sharesCase reports whether two nouns have nominal parses in the same case
+func (a *Analyzer) sharesCase(w1, w2 string) bool {
+	parses2 := a.parses(w2)
+	for _, p1 := range a.parses(w1) {
+		if !isNominal(tagPOS(p1.tag)) {
+			continue
+		}
+		c1 := baseCase(tagGrammeme(p1.tag, caseGrammemes))
+		for _, p2 := range parses2 {
+			if isNominal(tagPOS(p2.tag)) && baseCase(tagGrammeme(p2.tag, caseGrammemes)) == c1 {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// canBeGenitive reports whether word has a nominal parse in the genitive
+func (a *Analyzer) canBeGenitive(word string) bool {
+	for _, wp := range a.parses(word) {
+		if !isNominal(tagPOS(wp.tag)) {
+			continue
+		}
+		if cas := tagGrammeme(wp.tag, caseGrammemes); cas == "gent" || cas == "gen2" {
+			return true
+		}
+	}
+	return false
+}
+
+// coordinators are the conjunctions that join parallel members of a phrase
+var coordinators = map[string]bool{"и": true, "или": true, "а": true}
+
+// hasCoordination reports whether words contain a coordinating conjunction
+func hasCoordination(words []string) bool {
+	for _, w := range words {
+		if coordinators[w] {
+			return true
+		}
+	}
+	return false
+}
+
+// isProperNoun reports whether tag marks a name, surname, patronymic,
+// place or organisation
+func isProperNoun(tag string) bool {
+	for _, g := range []string{"Name", "Surn", "Patr", "Geox", "Orgn", "Trad"} {
+		if strings.Contains(tag, g) {
+			return true
+		}
+	}
+	return false
+}
+
+// isNominal reports whether pos can head a noun phrase
+func isNominal(pos string) bool {
+	return pos == "NOUN" || pos == "NPRO"
+}
+
+// isModifier reports whether pos agrees with a noun like an adjective
+func isModifier(pos string) bool {
+	return pos == "ADJF" || pos == "PRTF"
+}
+
+// declinePhrase declines every noun of the head group of p to cas/number and
+// agrees the adjectives and participles with the noun they attach to
+// A counted group follows the government of its numeral instead
+// Words that cannot be declined are kept unchanged and reported via false,
+// as is a case the opening preposition does not govern or a number the
+// numeral does not allow
+func (a *Analyzer) declinePhrase(p phraseAnalysis, cas, number string) (string, bool) {
+	base := baseCase(cas)
+	ok := p.allows(cas) && p.allowsNumber(number)
+	var plan numeralPlan
+	if p.numeral != nil {
+		head := p.words[p.head]
+		plan = p.numeral.plan(cas, head.gender, head.animacy)
+	}
+
+	declined := make([]string, len(p.words))
+	for i, w := range p.words {
+		declined[i] = w.text
+		if w.fixed {
+			continue
+		}
+		var (
+			form    string
+			matched bool
+		)
+		switch {
+		case p.numeral != nil && p.numeral.contains(i):
+			if w.pos != "NUMR" {
+				continue
+			}
+			head := p.words[p.head]
+			form, matched = a.inflectNumeral(w.text, w.parse.entry, plan.numCase, head.gender, plan.numAnimacy)
+		case isNominal(w.pos):
+			// Indeclinable nouns keep their form; their modifiers still
+			// agree with the dictionary gender
+			if strings.Contains(w.parse.tag, "Fixd") {
+				continue
+			}
+			n := a.memberNumber(p, i, number)
+			c := a.nounCase(p, w.parse.entry, cas, n)
+			if p.numeral != nil {
+				c, n = plan.nounCase, plan.nounNumber
+			}
+			form, matched = a.inflectEntry(w.text, w.parse.entry, c, n, "", "")
+			if s, ok := countedGenitives[form]; ok && p.numeral != nil && c == "gent" && n == "plur" {
+				form = s
+			}
+		case w.pos == "ADJF" || w.pos == "PRTF":
+			noun := p.words[w.attach]
+			c, n := base, a.memberNumber(p, w.attach, number)
+			if p.numeral != nil {
+				c, n = plan.adjCase, plan.adjNumber
+			}
+			c, g := adjAgreement(c, n, noun.gender, noun.animacy)
+			form, matched = a.inflectEntry(w.text, w.parse.entry, c, n, g, "")
+		default:
+			continue
+		}
+		if matched {
+			declined[i] = form
+		} else {
+			ok = false
+		}
+	}
+	a.applyEuphony(declined)
+	return strings.Join(declined, " "), ok
+}
+
+// countedGenitives maps genitive plurals to the suppletive forms used after
+// numerals: "пять лет", not "пять годов"; "пять человек", not "пять людей"
+var countedGenitives = map[string]string{"годов": "лет", "людей": "человек"}
+
+// agreementReadings returns every combination of parses in which all
+// adjectives and participles of p agree with the head in case, number,
+// gender and animacy
+// Each reading is indexed like p.words; words outside the agreeing group hold
+// a zero parse. Readings follow the POS-priority order of the head parses
+func (a *Analyzer) agreementReadings(p phraseAnalysis) [][]parse {
+	modParses := make(map[int][]parse)
+	for i, w := range p.words {
+		if w.attach == p.head && (w.pos == "ADJF" || w.pos == "PRTF") {
+			modParses[i] = a.parses(w.text)
+		}
+	}
+
+	var readings [][]parse
+	for _, hp := range a.parses(p.words[p.head].text) {
+		if !isNominal(tagPOS(hp.tag)) {
+			continue
+		}
+		r := make([]parse, len(p.words))
+		r[p.head] = hp
+		consistent := true
+		for i, candidates := range modParses {
+			found := false
+			for _, mp := range candidates {
+				pos := tagPOS(mp.tag)
+				if (pos == "ADJF" || pos == "PRTF") && agrees(hp.tag, mp.tag) {
+					r[i] = mp
+					found = true
+					break
+				}
+			}
+			if !found {
+				consistent = false
+				break
+			}
+		}
+		if consistent {
+			readings = append(readings, r)
+		}
+	}
+	return readings
+}
+
+// withReading returns a copy of p whose agreeing words use the parses of r
+func (p phraseAnalysis) withReading(r []parse) phraseAnalysis {
+	words := make([]phraseWord, len(p.words))
+	copy(words, p.words)
+	for i, rp := range r {
+		if rp.tag == "" {
+			continue
+		}
+		words[i].parse = rp
+		words[i].pos = tagPOS(rp.tag)
+		words[i].animacy = tagGrammeme(rp.tag, animacyGrammemes)
+		words[i].gender = tagGrammeme(rp.tag, genderGrammemes)
+	}
+	q := p
+	q.words = words
+	return q
+}
+
+// parses returns every dictionary analysis of word ordered by POS priority
+func (a *Analyzer) parses(word string) []parse {
+	entries := a.words.get(word)
+	result := make([]parse, 0, len(entries))
+	for _, e := range entries {
+		if t := a.entryTag(e); t != "" {
+			result = append(result, parse{entry: e, tag: t})
+		}
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		return a.entryPriority(result[i].entry) < a.entryPriority(result[j].entry)
+	})
+	return result
+}
+
+// agrees reports whether a modifier with tag mod can agree with a head noun
+// with tag head: same case and number, same gender in the singular unless
+// the noun is of common gender, and same animacy where the modifier marks it
+func agrees(head, mod string) bool {
+	number := tagGrammeme(head, numberGrammemes)
+	if tagGrammeme(mod, caseGrammemes) != baseCase(tagGrammeme(head, caseGrammemes)) ||
+		tagGrammeme(mod, numberGrammemes) != number {
+		return false
+	}
+	if g := tagGrammeme(head, genderGrammemes); number == "sing" && g != "" && !strings.Contains(mod, g) {
+		return false
+	}
+	if an := tagGrammeme(mod, animacyGrammemes); an != "" && !strings.Contains(head, an) {
+		return false
+	}
+	return true
+}
+
+// memberNumber returns the number the noun at index i takes when the phrase
+// is declined to number: parallel members lacking that number, such as a
+// singular-only proper noun in apposition, keep their own
+func (a *Analyzer) memberNumber(p phraseAnalysis, i int, number string) string {
+	w := p.words[i]
+	if i == p.head || a.hasForm(w.parse.entry, "", number) {
+		return number
+	}
+	return tagGrammeme(w.parse.tag, numberGrammemes)
+}
+
+// hasForm reports whether the lexeme of e has a form with the given case and number
+func (a *Analyzer) hasForm(e wordEntry, cas, number string) bool {
+	para := a.paradigms[e.paradigmID]
+	n := len(para) / 3
+	for i := 0; i < n; i++ {
+		if tagMatches(a.gramtab[para[n+i]], cas, number, "", "") {
+			return true
+		}
+	}
+	return false
+}
+
+// baseCase maps the second genitive and locative and the vocative to the
+// ordinary cases they fall back to; other cases are returned unchanged
+func baseCase(cas string) string {
+	switch cas {
+	case "gen2":
+		return "gent"
+	case "loc2":
+		return "loct"
+	case "voct":
+		return "nomn"
+	}
+	return cas
+}
+
+// nounCase returns the case a noun of the lexeme e takes when its phrase is
+// declined to cas: gen2, loc2 and voct fall back to their base case when the
+// lexeme lacks them, and the locative after a preposition that governs loc2
+// becomes loc2 when the lexeme has it ("в саду", "на берегу")
+func (a *Analyzer) nounCase(p phraseAnalysis, e wordEntry, cas, number string) string {
+	if cas == "loct" && slices.Contains(p.governed, "loc2") && a.hasForm(e, "loc2", number) {
+		return "loc2"
+	}
+	if cas != baseCase(cas) && !a.hasForm(e, cas, number) {
+		return baseCase(cas)
+	}
+	return cas
+}
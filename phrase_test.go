@@ -0,0 +1,587 @@
+package gomorphy
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestPhraseFormsTable(t *testing.T) {
+	a := testAnalyzer
+
+	tests := []struct {
+		phrase string
+		want   map[Cell]string
+	}{
+		{
+			phrase: "красивая кошка",
+			want: map[Cell]string{
+				{"nomn", "sing"}: "красивая кошка",
+				{"gent", "sing"}: "красивой кошки",
+				{"accs", "sing"}: "красивую кошку",
+				{"datv", "plur"}: "красивым кошкам",
+				{"ablt", "plur"}: "красивыми кошками",
+			},
+		},
+		{
+			// Second locative is present because the head has it
+			phrase: "густой лес",
+			want: map[Cell]string{
+				{"loct", "sing"}: "густом лесе",
+				{"loc2", "sing"}: "густом лесу",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.phrase, func(t *testing.T) {
+			table := a.PhraseFormsTable(tt.phrase)
+			if table == nil {
+				t.Fatalf("PhraseFormsTable(%q) = nil", tt.phrase)
+			}
+			if table.Original != tt.phrase {
+				t.Errorf("Original = %q, want %q", table.Original, tt.phrase)
+			}
+			for cell, want := range tt.want {
+				if got := table.Get(cell.Case, cell.Number); got != want {
+					t.Errorf("Get(%q, %q) = %q, want %q", cell.Case, cell.Number, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestPhraseFormsTable_EdgeCases(t *testing.T) {
+	a := testAnalyzer
+
+	t.Run("empty string", func(t *testing.T) {
+		if got := a.PhraseFormsTable(""); got != nil {
+			t.Errorf("PhraseFormsTable(\"\") = %v, want nil", got)
+		}
+	})
+
+	t.Run("no gen2 without dictionary support", func(t *testing.T) {
+		table := a.PhraseFormsTable("красивая кошка")
+		if got := table.Get("gen2", "sing"); got != "" {
+			t.Errorf("Get(gen2, sing) = %q, want empty", got)
+		}
+	})
+
+	t.Run("canonical order", func(t *testing.T) {
+		cells := a.PhraseFormsTable("красивая кошка").Cells()
+		if len(cells) == 0 {
+			t.Fatal("Cells() returned empty slice")
+		}
+		if cells[0] != (Cell{"nomn", "sing"}) {
+			t.Errorf("Cells()[0] = %v, want nomn sing", cells[0])
+		}
+	})
+}
+
+func TestInflectPhrase(t *testing.T) {
+	a := testAnalyzer
+
+	tests := []struct {
+		phrase, cas, number string
+		want                string
+	}{
+		{"красивая кошка", "datv", "plur", "красивым кошкам"},
+		{"красивая кошка", "accs", "sing", "красивую кошку"},
+		// Inanimate masculine accusative adjective equals nominative
+		{"большой стол", "accs", "sing", "большой стол"},
+		// Animate masculine accusative adjective equals genitive
+		{"новый пользователь", "accs", "plur", "новых пользователей"},
+		{"новых пользователей", "nomn", "sing", "новый пользователь"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.phrase+"/"+tt.cas+"/"+tt.number, func(t *testing.T) {
+			got, ok := a.InflectPhrase(tt.phrase, tt.cas, tt.number)
+			if !ok {
+				t.Fatalf("InflectPhrase(%q, %q, %q) reported failure", tt.phrase, tt.cas, tt.number)
+			}
+			if got != tt.want {
+				t.Errorf("InflectPhrase(%q, %q, %q) = %q, want %q", tt.phrase, tt.cas, tt.number, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestInflectPhrase_EdgeCases(t *testing.T) {
+	a := testAnalyzer
+
+	t.Run("empty string", func(t *testing.T) {
+		if _, ok := a.InflectPhrase("", "datv", "sing"); ok {
+			t.Error("InflectPhrase(\"\") reported success")
+		}
+	})
+
+	t.Run("no noun", func(t *testing.T) {
+		if _, ok := a.InflectPhrase("быстро читать", "datv", "sing"); ok {
+			t.Error("InflectPhrase without a noun reported success")
+		}
+	})
+
+	t.Run("unknown case", func(t *testing.T) {
+		if _, ok := a.InflectPhrase("красивая кошка", "xxxx", "sing"); ok {
+			t.Error("InflectPhrase with unknown case reported success")
+		}
+	})
+
+	t.Run("empty or partial grammemes", func(t *testing.T) {
+		tests := []struct{ cas, number string }{
+			{"", ""},
+			{"", "sing"},
+			{"datv", ""},
+			{"nom", "sing"},
+			{"datv", "pl"},
+			{"DATV", "sing"},
+		}
+		for _, tt := range tests {
+			if got, ok := a.InflectPhrase("красивая кошка", tt.cas, tt.number); ok {
+				t.Errorf("InflectPhrase(красивая кошка, %q, %q) = %q, true; want false", tt.cas, tt.number, got)
+			}
+		}
+	})
+}
+
+func TestNormalizePhrase(t *testing.T) {
+	a := testAnalyzer
+
+	tests := []struct {
+		phrase string
+		want   string
+	}{
+		{"красивой кошке", "красивая кошка"},
+		{"красивую кошку", "красивая кошка"},
+		{"новых пользователей", "новый пользователь"},
+		{"большим столом", "большой стол"},
+		// Plural-only noun keeps its number
+		{"острых ножниц", "острые ножницы"},
+		// Already normalized
+		{"красивая кошка", "красивая кошка"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.phrase, func(t *testing.T) {
+			got, ok := a.NormalizePhrase(tt.phrase)
+			if !ok {
+				t.Fatalf("NormalizePhrase(%q) reported failure", tt.phrase)
+			}
+			if got != tt.want {
+				t.Errorf("NormalizePhrase(%q) = %q, want %q", tt.phrase, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNormalizePhrase_EdgeCases(t *testing.T) {
+	a := testAnalyzer
+
+	t.Run("empty string", func(t *testing.T) {
+		if _, ok := a.NormalizePhrase(""); ok {
+			t.Error("NormalizePhrase(\"\") reported success")
+		}
+	})
+
+	t.Run("no noun", func(t *testing.T) {
+		if _, ok := a.NormalizePhrase("быстро"); ok {
+			t.Error("NormalizePhrase without a noun reported success")
+		}
+	})
+}
+
+func TestAgrees(t *testing.T) {
+	tests := []struct {
+		head, mod string
+		want      bool
+	}{
+		{"NOUN,inan,femn sing,datv", "ADJF,Qual femn,sing,datv", true},
+		{"NOUN,inan,femn sing,datv", "ADJF,Qual masc,sing,datv", false},
+		{"NOUN,inan,femn sing,datv", "ADJF,Qual femn,sing,gent", false},
+		{"NOUN,anim,masc plur,gent", "ADJF,Qual plur,gent", true},
+		{"NOUN,inan,masc sing,accs", "ADJF,Qual inan,masc,sing,accs", true},
+		{"NOUN,inan,masc sing,accs", "ADJF,Qual anim,masc,sing,accs", false},
+		// Second locative agrees with the ordinary locative
+		{"NOUN,inan,masc sing,loc2", "ADJF,Qual masc,sing,loct", true},
+		// Common gender nouns accept either gender
+		{"NOUN,anim,ms-f sing,nomn", "ADJF,Qual femn,sing,nomn", true},
+	}
+	for _, tt := range tests {
+		if got := agrees(tt.head, tt.mod); got != tt.want {
+			t.Errorf("agrees(%q, %q) = %v, want %v", tt.head, tt.mod, got, tt.want)
+		}
+	}
+}
+
+func TestPhraseReadings(t *testing.T) {
+	a := testAnalyzer
+
+	tests := []struct {
+		phrase string
+		want   []Cell
+	}{
+		{"красивой кошке", []Cell{{"datv", "sing"}, {"loct", "sing"}}},
+		{"красивые столы", []Cell{{"nomn", "plur"}, {"accs", "plur"}}},
+		// "кошки" alone is also genitive singular; the adjective rules it out
+		{"красивые кошки", []Cell{{"nomn", "plur"}}},
+		{"новых пользователей", []Cell{{"gent", "plur"}, {"accs", "plur"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.phrase, func(t *testing.T) {
+			got := a.PhraseReadings(tt.phrase)
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("PhraseReadings(%q) = %v, want %v", tt.phrase, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPhraseReadings_EdgeCases(t *testing.T) {
+	a := testAnalyzer
+
+	t.Run("empty string", func(t *testing.T) {
+		if got := a.PhraseReadings(""); got != nil {
+			t.Errorf("PhraseReadings(\"\") = %v, want nil", got)
+		}
+	})
+
+	t.Run("disagreeing words", func(t *testing.T) {
+		if got := a.PhraseReadings("красивый кошками"); got != nil {
+			t.Errorf("PhraseReadings(disagreeing) = %v, want nil", got)
+		}
+	})
+}
+
+func TestPhraseFormsConcordant_Prepositions(t *testing.T) {
+	a := testAnalyzer
+
+	tests := []struct {
+		phrase   string
+		contains []string
+		excludes []string
+	}{
+		{
+			// Only the cases governed by "в" are generated
+			phrase:   "в красивой кошке",
+			contains: []string{"в красивой кошке", "в красивую кошку", "в красивых кошках"},
+			excludes: []string{"в красивая кошка", "в красивой кошки"},
+		},
+		{
+			// The dependent prepositional group keeps its case
+			phrase:   "книга о красивой кошке",
+			contains: []string{"книги о красивой кошке", "книгам о красивой кошке"},
+			excludes: []string{"книги о красивой кошки"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.phrase, func(t *testing.T) {
+			forms := a.PhraseFormsConcordant(tt.phrase)
+			for _, want := range tt.contains {
+				if !slices.Contains(forms, want) {
+					t.Errorf("PhraseFormsConcordant(%q) does not contain %q; got %v", tt.phrase, want, forms)
+				}
+			}
+			for _, bad := range tt.excludes {
+				if slices.Contains(forms, bad) {
+					t.Errorf("PhraseFormsConcordant(%q) contains %q", tt.phrase, bad)
+				}
+			}
+		})
+	}
+}
+
+func TestInflectPhrase_Prepositions(t *testing.T) {
+	a := testAnalyzer
+
+	if got, ok := a.InflectPhrase("в большом городе", "accs", "plur"); !ok || got != "в большие города" {
+		t.Errorf("InflectPhrase(в большом городе, accs, plur) = %q, %v; want %q", got, ok, "в большие города")
+	}
+	if _, ok := a.InflectPhrase("в большом городе", "datv", "sing"); ok {
+		t.Error("InflectPhrase into a case not governed by \"в\" reported success")
+	}
+	if got := a.PhraseReadings("в красивой кошке"); !slices.Equal(got, []Cell{{"loct", "sing"}}) {
+		t.Errorf("PhraseReadings(в красивой кошке) = %v, want [loct sing]", got)
+	}
+}
+
+func TestAnalyzePhrase_KeepsGovernment(t *testing.T) {
+	a := testAnalyzer
+
+	p := a.analyzePhrase([]string{"в", "большом", "городе"})
+	if p.head != 2 {
+		t.Fatalf("analyzePhrase(в большом городе) head = %d, want 2", p.head)
+	}
+	if !slices.Equal(p.governed, PrepositionCases("в")) {
+		t.Errorf("analyzePhrase(в большом городе) governed = %v, want %v", p.governed, PrepositionCases("в"))
+	}
+
+	p = a.analyzePhrase([]string{"пять", "новых", "файлов"})
+	if p.head != 2 {
+		t.Fatalf("analyzePhrase(пять новых файлов) head = %d, want 2", p.head)
+	}
+	if p.numeral == nil || !slices.Equal(p.numeral.words, []int{0}) {
+		t.Errorf("analyzePhrase(пять новых файлов) numeral = %+v, want words [0]", p.numeral)
+	}
+}
+
+func TestInflectPhrase_SecondCases(t *testing.T) {
+	a := testAnalyzer
+
+	tests := []struct {
+		phrase, cas, number string
+		want                string
+	}{
+		// A preposition governing loc2 picks it for the locative
+		{"в сад", "loct", "sing", "в саду"},
+		{"на берег", "loct", "sing", "на берегу"},
+		{"в густой лес", "loct", "sing", "в густом лесу"},
+		// "о" governs only the ordinary locative
+		{"о саде", "loct", "sing", "о саде"},
+		{"густой лес", "loc2", "sing", "густом лесу"},
+		{"чай", "gen2", "sing", "чаю"},
+		// Lexemes without the second cases fall back to the base case
+		{"в дом", "loct", "sing", "в доме"},
+		{"красивая кошка", "gen2", "sing", "красивой кошки"},
+		{"красивая кошка", "loc2", "sing", "красивой кошке"},
+		{"красивая кошка", "voct", "sing", "красивая кошка"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.phrase+"/"+tt.cas, func(t *testing.T) {
+			got, ok := a.InflectPhrase(tt.phrase, tt.cas, tt.number)
+			if !ok || got != tt.want {
+				t.Errorf("InflectPhrase(%q, %q, %q) = %q, %v; want %q", tt.phrase, tt.cas, tt.number, got, ok, tt.want)
+			}
+		})
+	}
+
+	if got := a.PhraseFormsTable("маша").Get("voct", "sing"); got != "маш" {
+		t.Errorf("PhraseFormsTable(маша) voct = %q, want %q", got, "маш")
+	}
+	if got := a.PhraseFormsTable("красивая кошка").Get("voct", "sing"); got != "" {
+		t.Errorf("PhraseFormsTable(красивая кошка) voct = %q, want empty", got)
+	}
+}
+
+func TestInflectPhrase_Euphony(t *testing.T) {
+	a := testAnalyzer
+
+	tests := []struct {
+		phrase, cas, number string
+		want                string
+	}{
+		{"о окно", "loct", "sing", "об окне"},
+		{"об окне", "loct", "plur", "об окнах"},
+		{"в вторник", "accs", "sing", "во вторник"},
+		{"с стол", "gent", "sing", "со стола"},
+		{"с красивая кошка", "ablt", "sing", "с красивой кошкой"},
+		{"к сон", "datv", "sing", "ко сну"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.phrase+"/"+tt.cas, func(t *testing.T) {
+			got, ok := a.InflectPhrase(tt.phrase, tt.cas, tt.number)
+			if !ok || got != tt.want {
+				t.Errorf("InflectPhrase(%q, %q, %q) = %q, %v; want %q", tt.phrase, tt.cas, tt.number, got, ok, tt.want)
+			}
+		})
+	}
+}
+
+func TestPhraseFormsTable_LexemeNumber(t *testing.T) {
+	a := testAnalyzer
+
+	tests := []struct {
+		phrase  string
+		want    map[Cell]string
+		missing []Cell
+	}{
+		{
+			phrase: "острые ножницы",
+			want: map[Cell]string{
+				{"datv", "plur"}: "острым ножницам",
+				{"ablt", "plur"}: "острыми ножницами",
+			},
+			missing: []Cell{{"nomn", "sing"}, {"gent", "sing"}, {"datv", "sing"}, {"accs", "sing"}, {"ablt", "sing"}, {"loct", "sing"}},
+		},
+		{
+			phrase: "свежее молоко",
+			want: map[Cell]string{
+				{"gent", "sing"}: "свежего молока",
+				{"ablt", "sing"}: "свежим молоком",
+			},
+			missing: []Cell{{"nomn", "plur"}, {"gent", "plur"}, {"datv", "plur"}, {"accs", "plur"}, {"ablt", "plur"}, {"loct", "plur"}},
+		},
+		{
+			// Indeclinable nouns keep their form, adjectives agree with their gender
+			phrase: "новое пальто",
+			want: map[Cell]string{
+				{"datv", "sing"}: "новому пальто",
+				{"ablt", "plur"}: "новыми пальто",
+			},
+		},
+		{
+			phrase: "чёрный кофе",
+			want: map[Cell]string{
+				{"gent", "sing"}: "чёрного кофе",
+				{"loct", "sing"}: "чёрном кофе",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.phrase, func(t *testing.T) {
+			table := a.PhraseFormsTable(tt.phrase)
+			for cell, want := range tt.want {
+				if got := table.Get(cell.Case, cell.Number); got != want {
+					t.Errorf("Get(%q, %q) = %q, want %q", cell.Case, cell.Number, got, want)
+				}
+			}
+			if got := table.Missing(); !slices.Equal(got, tt.missing) {
+				t.Errorf("Missing() = %v, want %v", got, tt.missing)
+			}
+
+			// The flat list holds nothing the table does not
+			forms := make(map[string]bool)
+			for _, f := range table.Forms {
+				forms[f] = true
+			}
+			for _, f := range a.PhraseFormsConcordant(tt.phrase)[1:] {
+				if !forms[f] {
+					t.Errorf("PhraseFormsConcordant(%q) has %q, which is not in the table", tt.phrase, f)
+				}
+			}
+		})
+	}
+
+	if _, ok := a.InflectPhrase("острые ножницы", "datv", "sing"); ok {
+		t.Error("InflectPhrase of a plural-only noun into the singular reported success")
+	}
+}
+
+func TestInflectPhrase_GenitiveChains(t *testing.T) {
+	a := testAnalyzer
+
+	tests := []struct {
+		phrase, cas, number string
+		want                string
+	}{
+		// Dependent genitives stay fixed
+		{"стол директора школы", "datv", "plur", "столам директора школы"},
+		// The modifier of a dependent genitive stays fixed with it
+		{"книга нового директора", "datv", "sing", "книге нового директора"},
+		// Postpositive adjective agrees with the preceding noun
+		{"кошка красивая", "ablt", "sing", "кошкой красивой"},
+		{"большой стол директора", "loct", "plur", "больших столах директора"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.phrase, func(t *testing.T) {
+			got, ok := a.InflectPhrase(tt.phrase, tt.cas, tt.number)
+			if !ok || got != tt.want {
+				t.Errorf("InflectPhrase(%q, %q, %q) = %q, %v; want %q", tt.phrase, tt.cas, tt.number, got, ok, tt.want)
+			}
+		})
+	}
+}
+
+func TestInflectPhrase_Coordination(t *testing.T) {
+	a := testAnalyzer
+
+	tests := []struct {
+		phrase, cas, number string
+		want                string
+	}{
+		{"кошка и собака", "datv", "plur", "кошкам и собакам"},
+		{"кошка или собака", "ablt", "sing", "кошкой или собакой"},
+		// Both adjectives agree with the shared noun
+		{"красные и синие шары", "gent", "plur", "красных и синих шаров"},
+		// Each conjunct keeps its own modifier and gender
+		{"новая кошка и старый кот", "datv", "sing", "новой кошке и старому коту"},
+		// Apposition declines with the head; the place name stays singular
+		{"город москва", "ablt", "sing", "городом москвой"},
+		{"город москва", "gent", "plur", "городов москвы"},
+		// A proper noun that can be genitive depends on a common noun
+		{"улица иванова", "datv", "sing", "улице иванова"},
+		{"дом пушкина", "ablt", "plur", "домами пушкина"},
+		// Surname in the genitive is a dependent, not an apposition
+		{"книга пушкина", "datv", "sing", "книге пушкина"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.phrase+"/"+tt.cas+"/"+tt.number, func(t *testing.T) {
+			got, ok := a.InflectPhrase(tt.phrase, tt.cas, tt.number)
+			if !ok || got != tt.want {
+				t.Errorf("InflectPhrase(%q, %q, %q) = %q, %v; want %q", tt.phrase, tt.cas, tt.number, got, ok, tt.want)
+			}
+		})
+	}
+}
+
+func TestPhraseParses(t *testing.T) {
+	a := testAnalyzer
+
+	tests := []struct {
+		phrase  string
+		wantPOS []string
+	}{
+		// POS priority alone would read "простой" as the noun (downtime)
+		{"простой вопрос", []string{"ADJF", "NOUN"}},
+		{"столовая ложка", []string{"ADJF", "NOUN"}},
+		{"новое стекло", []string{"ADJF", "NOUN"}},
+		{"в большом городе", []string{"", "ADJF", "NOUN"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.phrase, func(t *testing.T) {
+			parses := a.PhraseParses(tt.phrase)
+			if len(parses) != len(tt.wantPOS) {
+				t.Fatalf("PhraseParses(%q) returned %d parses, want %d", tt.phrase, len(parses), len(tt.wantPOS))
+			}
+			for i, want := range tt.wantPOS {
+				if got := tagPOS(parses[i].Tag); got != want {
+					t.Errorf("PhraseParses(%q)[%d] POS = %q, want %q (tag %q)", tt.phrase, i, got, want, parses[i].Tag)
+				}
+			}
+		})
+	}
+
+	t.Run("consistent case", func(t *testing.T) {
+		parses := a.PhraseParses("красивой кошке")
+		for _, p := range parses {
+			if tagGrammeme(p.Tag, caseGrammemes) != tagGrammeme(parses[1].Tag, caseGrammemes) {
+				t.Errorf("PhraseParses(красивой кошке) mixes cases: %v", parses)
+			}
+		}
+	})
+
+	t.Run("empty string", func(t *testing.T) {
+		if got := a.PhraseParses(""); got != nil {
+			t.Errorf("PhraseParses(\"\") = %v, want nil", got)
+		}
+	})
+}
+
+func TestInflectPhrase_Disambiguation(t *testing.T) {
+	a := testAnalyzer
+
+	if got, ok := a.InflectPhrase("простой вопрос", "datv", "plur"); !ok || got != "простым вопросам" {
+		t.Errorf("InflectPhrase(простой вопрос, datv, plur) = %q, %v; want %q", got, ok, "простым вопросам")
+	}
+
+	// "сорока" is a bird unless a noun follows for the numeral to count
+	if got, ok := a.InflectPhrase("белая сорока", "datv", "sing"); !ok || got != "белой сороке" {
+		t.Errorf("InflectPhrase(белая сорока, datv, sing) = %q, %v; want %q", got, ok, "белой сороке")
+	}
+	if got, ok := a.NormalizePhrase("белой сороки"); !ok || got != "белая сорока" {
+		t.Errorf("NormalizePhrase(белой сороки) = %q, %v; want %q", got, ok, "белая сорока")
+	}
+	if got := a.PhraseFormsTable("сорока").Get("ablt", "sing"); got != "сорокой" {
+		t.Errorf("PhraseFormsTable(сорока) ablt sing = %q, want %q", got, "сорокой")
+	}
+	if got, ok := a.InflectPhrase("сорок кошек", "datv", "plur"); !ok || got != "сорока кошкам" {
+		t.Errorf("InflectPhrase(сорок кошек, datv, plur) = %q, %v; want %q", got, ok, "сорока кошкам")
+	}
+}
@@ -0,0 +1,121 @@
+package gomorphy
+
+import (
+	"strconv"
+	"strings"
+)
+
+// CLDR plural categories of Russian
+const (
+	PluralOne   = "one"
+	PluralFew   = "few"
+	PluralMany  = "many"
+	PluralOther = "other"
+)
+
+// PluralCategory returns the CLDR plural category of an integer under the
+// Russian rules: one for 1, 21, 101…, few for 2–4, 22–24…, many for 0,
+// 5–20, 25–30… The sign is ignored
+func PluralCategory(n int64) string {
+	u := uint64(n)
+	if n < 0 {
+		u = -u
+	}
+	return digitsCategory(strconv.FormatUint(u, 10))
+}
+
+// DecimalPluralCategory returns the CLDR plural category of a number
+// written in digits with an optional sign and fraction, such as "21",
+// "-3" or "1.5"; a comma also separates the fraction
+//
+// Any visible fraction digits, even zeros ("1.0"), make the category other,
+// as CLDR specifies for Russian
+// Reports false if s is not a number
+func DecimalPluralCategory(s string) (string, bool) {
+	s = strings.TrimPrefix(strings.TrimPrefix(s, "-"), "+")
+	whole, fraction, hasFraction := strings.Cut(strings.ReplaceAll(s, ",", "."), ".")
+	if !isDigits(whole) || hasFraction && !isDigits(fraction) {
+		return "", false
+	}
+	if hasFraction {
+		return PluralOther, true
+	}
+	return digitsCategory(whole), true
+}
+
+// PluralVariants are the forms of a counted phrase for each CLDR category,
+// as message catalogs store them
+type PluralVariants struct {
+	One   string // "новое сообщение", after 1, 21, 101…
+	Few   string // "новых сообщения", after 2–4, 22–24…
+	Many  string // "новых сообщений", after 0, 5–20, 25–30…
+	Other string // "нового сообщения", after fractions such as 1.5
+}
+
+// PluralForms generates the catalog variants of a phrase counted by a
+// number in the given case, e.g. PluralForms("новое сообщение", "nomn") →
+// {"новое сообщение", "новых сообщения", "новых сообщений", "нового сообщения"}
+//
+// One, Few and Many follow the numeral government of [Analyzer.CountPhrase];
+// Other puts the phrase in the genitive singular, which is what fractions
+// take ("1,5 минуты") in every case
+//
+// In the accusative of an animate phrase a simple 2–4 and a compound 22–24
+// take different forms ("2 красивых кошек" but "22 красивые кошки") that
+// share the few category. Few then holds the compound form, so Select(2)
+// differs from CountPhrase(2, phrase, "accs") for animate nouns
+// Reports false if the phrase has no noun or a word cannot be inflected
+func (a *Analyzer) PluralForms(phrase, cas string) (PluralVariants, bool) {
+	few := int64(2)
+	if baseCase(cas) == "accs" {
+		few = 22
+	}
+	var v PluralVariants
+	for _, f := range []struct {
+		n    int64
+		dest *string
+	}{{1, &v.One}, {few, &v.Few}, {5, &v.Many}} {
+		s, ok := a.CountPhrase(f.n, phrase, cas)
+		if !ok {
+			return PluralVariants{}, false
+		}
+		*f.dest = s
+	}
+	other, ok := a.InflectPhrase(phrase, "gent", "sing")
+	if !ok {
+		return PluralVariants{}, false
+	}
+	v.Other = other
+	return v, true
+}
+
+// Select returns the variant for the category of n
+func (v PluralVariants) Select(n int64) string {
+	return v.Get(PluralCategory(n))
+}
+
+// Get returns the variant for a CLDR category, or an empty string for an
+// unknown one
+func (v PluralVariants) Get(category string) string {
+	switch category {
+	case PluralOne:
+		return v.One
+	case PluralFew:
+		return v.Few
+	case PluralMany:
+		return v.Many
+	case PluralOther:
+		return v.Other
+	}
+	return ""
+}
+
+// Map returns the variants keyed by CLDR category
+func (v PluralVariants) Map() map[string]string {
+	return map[string]string{
+		PluralOne:   v.One,
+		PluralFew:   v.Few,
+		PluralMany:  v.Many,
+		PluralOther: v.Other,
+	}
+}
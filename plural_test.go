@@ -0,0 +1,99 @@
+package gomorphy
+
+import "testing"
+
+func TestPluralCategory(t *testing.T) {
+	tests := []struct {
+		n    int64
+		want string
+	}{
+		{0, PluralMany},
+		{1, PluralOne},
+		{2, PluralFew},
+		{4, PluralFew},
+		{5, PluralMany},
+		{11, PluralMany},
+		{12, PluralMany},
+		{14, PluralMany},
+		{21, PluralOne},
+		{22, PluralFew},
+		{111, PluralMany},
+		{101, PluralOne},
+		{1000, PluralMany},
+		{-3, PluralFew},
+	}
+
+	for _, tt := range tests {
+		if got := PluralCategory(tt.n); got != tt.want {
+			t.Errorf("PluralCategory(%d) = %q, want %q", tt.n, got, tt.want)
+		}
+	}
+}
+
+func TestDecimalPluralCategory(t *testing.T) {
+	tests := []struct {
+		s    string
+		want string
+		ok   bool
+	}{
+		{"21", PluralOne, true},
+		{"-3", PluralFew, true},
+		{"11", PluralMany, true},
+		{"1.5", PluralOther, true},
+		{"1,5", PluralOther, true},
+		{"1.0", PluralOther, true},
+		{"", "", false},
+		{"1.", "", false},
+		{"пять", "", false},
+	}
+
+	for _, tt := range tests {
+		got, ok := DecimalPluralCategory(tt.s)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("DecimalPluralCategory(%q) = %q, %v; want %q, %v", tt.s, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestPluralForms(t *testing.T) {
+	a := testAnalyzer
+
+	tests := []struct {
+		phrase, cas string
+		want        PluralVariants
+	}{
+		{"новое сообщение", "nomn", PluralVariants{"новое сообщение", "новых сообщения", "новых сообщений", "нового сообщения"}},
+		{"минута", "nomn", PluralVariants{"минута", "минуты", "минут", "минуты"}},
+		{"красивая кошка", "nomn", PluralVariants{"красивая кошка", "красивые кошки", "красивых кошек", "красивой кошки"}},
+		{"файл", "datv", PluralVariants{"файлу", "файлам", "файлам", "файла"}},
+	}
+
+	for _, tt := range tests {
+		got, ok := a.PluralForms(tt.phrase, tt.cas)
+		if !ok || got != tt.want {
+			t.Errorf("PluralForms(%q, %q) = %+v, %v; want %+v", tt.phrase, tt.cas, got, ok, tt.want)
+		}
+	}
+
+	v, _ := a.PluralForms("файл", "nomn")
+	for n, want := range map[int64]string{1: "файл", 3: "файла", 12: "файлов", 21: "файл"} {
+		if got := v.Select(n); got != want {
+			t.Errorf("Select(%d) = %q, want %q", n, got, want)
+		}
+	}
+	if got := v.Map()[PluralOther]; got != "файла" {
+		t.Errorf("Map()[other] = %q, want %q", got, "файла")
+	}
+
+	// Few holds the compound form in the animate accusative
+	v, _ = a.PluralForms("красивая кошка", "accs")
+	for _, n := range []int64{1, 5, 22, 24, 25} {
+		want, _ := a.CountPhrase(n, "красивая кошка", "accs")
+		if got := v.Select(n); got != want {
+			t.Errorf("PluralForms(красивая кошка, accs).Select(%d) = %q, want CountPhrase %q", n, got, want)
+		}
+	}
+	if v.Few != "красивые кошки" {
+		t.Errorf("PluralForms(красивая кошка, accs).Few = %q, want %q", v.Few, "красивые кошки")
+	}
+}
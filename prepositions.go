@@ -0,0 +1,227 @@
+package gomorphy
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// prepositionCases is the government table: the cases a preposition requires
+// of the noun phrase it introduces. loc2 and gen2 are listed only where the
+// preposition actually takes the second locative/genitive ("в лесу", "без сахару")
+var prepositionCases = map[string][]string{
+	"без":       {"gent", "gen2"},
+	"в":         {"accs", "loct", "loc2"},
+	"во":        {"accs", "loct", "loc2"},
+	"для":       {"gent"},
+	"до":        {"gent"},
+	"за":        {"accs", "ablt"},
+	"из":        {"gent", "gen2"},
+	"изо":       {"gent", "gen2"},
+	"из-за":     {"gent"},
+	"из-под":    {"gent"},
+	"к":         {"datv"},
+	"ко":        {"datv"},
+	"на":        {"accs", "loct", "loc2"},
+	"над":       {"ablt"},
+	"о":         {"accs", "loct"},
+	"об":        {"accs", "loct"},
+	"обо":       {"accs", "loct"},
+	"от":        {"gent"},
+	"ото":       {"gent"},
+	"по":        {"datv", "accs", "loct"},
+	"под":       {"accs", "ablt"},
+	"подо":      {"accs", "ablt"},
+	"при":       {"loct"},
+	"про":       {"accs"},
+	"с":         {"gent", "accs", "ablt"},
+	"со":        {"gent", "accs", "ablt"},
+	"у":         {"gent"},
+	"через":     {"accs"},
+	"сквозь":    {"accs"},
+	"между":     {"ablt", "gent"},
+	"среди":     {"gent"},
+	"около":     {"gent"},
+	"после":     {"gent"},
+	"перед":     {"ablt"},
+	"передо":    {"ablt"},
+	"вокруг":    {"gent"},
+	"против":    {"gent"},
+	"вместо":    {"gent"},
+	"кроме":     {"gent"},
+	"ради":      {"gent"},
+	"мимо":      {"gent"},
+	"возле":     {"gent"},
+	"благодаря": {"datv"},
+}
+
+// PrepositionCases returns the cases the given preposition governs,
+// e.g. "в" → [accs loct loc2], "к" → [datv]
+// Returns nil if prep is not a known preposition
+func PrepositionCases(prep string) []string {
+	cases, ok := prepositionCases[strings.ToLower(strings.TrimSpace(prep))]
+	if !ok {
+		return nil
+	}
+	out := make([]string, len(cases))
+	copy(out, cases)
+	return out
+}
+
+// isPreposition reports whether w is listed in the government table
+func isPreposition(w string) bool {
+	_, ok := prepositionCases[w]
+	return ok
+}
+
+// euphonyRule describes a preposition that takes an extra vowel before
+// some words to avoid an awkward cluster: "со стола", "во время", "обо мне"
+type euphonyRule struct {
+	short string // the plain form: "с"
+	vowel string // the form before a vowel, "об" for "о", otherwise short
+	long  string // the form with the extra vowel: "со"
+	// clusters lists the letters whose consonant clusters take the long
+	// form: "со стола", "во флоте"
+	clusters string
+	// fleeting marks prepositions taking the long form before a cluster
+	// left by a fleeting vowel: "со льда" (лёд), "во рту" (рот), "ко сну" (сон)
+	fleeting bool
+	words    map[string]bool // word forms taking the long form: "мне", "мной"
+	lemmas   map[string]bool // lexemes whose every form takes it: "весь"
+}
+
+// euphonyRules are keyed by every form of the preposition
+var euphonyRules = func() map[string]*euphonyRule {
+	all := map[string]bool{"весь": true}
+	rules := []*euphonyRule{
+		{short: "о", vowel: "об", long: "обо",
+			words: map[string]bool{"мне": true, "что": true}, lemmas: all},
+		{short: "с", long: "со", clusters: "сзшжщ", fleeting: true,
+			words:  map[string]bool{"мной": true, "мною": true},
+			lemmas: map[string]bool{"весь": true, "второй": true, "вторник": true}},
+		{short: "в", long: "во", clusters: "вф", fleeting: true,
+			words:  map[string]bool{"мне": true, "имя": true, "благо": true},
+			lemmas: map[string]bool{"весь": true, "двор": true, "дворец": true, "многое": true, "многий": true, "множество": true}},
+		{short: "к", long: "ко", fleeting: true,
+			words:  map[string]bool{"мне": true},
+			lemmas: map[string]bool{"весь": true, "второй": true, "многий": true}},
+		{short: "из", long: "изо", fleeting: true, lemmas: all},
+		{short: "от", long: "ото", fleeting: true, lemmas: all},
+		{short: "под", long: "подо", words: map[string]bool{"мной": true, "мною": true}, lemmas: all},
+		{short: "перед", long: "передо", words: map[string]bool{"мной": true, "мною": true}, lemmas: all},
+	}
+	m := make(map[string]*euphonyRule)
+	for _, r := range rules {
+		if r.vowel == "" {
+			r.vowel = r.short
+		}
+		m[r.short], m[r.vowel], m[r.long] = r, r, r
+	}
+	return m
+}()
+
+// PrepositionForm returns the form of a preposition suited to the word
+// that follows it, e.g. ("о", "окне") → "об", ("о", "мне") → "обо",
+// ("с", "стола") → "со", ("в", "время") → "во", ("к", "мне") → "ко"
+//
+// prep may be given in any of its forms. Besides the initial sounds of next,
+// the dictionary is consulted for lexemes that always take the long form
+// ("обо всём", "во дворе") and for clusters left by a fleeting vowel
+// ("со льда" from "лёд", "во сне" from "сон"). The capitalisation of prep
+// is kept; prepositions without variants are returned unchanged
+func (a *Analyzer) PrepositionForm(prep, next string) string {
+	r, ok := euphonyRules[strings.ToLower(prep)]
+	if !ok {
+		return prep
+	}
+	form := a.euphonicForm(r, strings.ToLower(strings.TrimSpace(next)))
+	if first, _ := utf8.DecodeRuneInString(prep); unicode.IsUpper(first) {
+		f, size := utf8.DecodeRuneInString(form)
+		form = string(unicode.ToUpper(f)) + form[size:]
+	}
+	return form
+}
+
+// euphonicForm picks the form of r before the lower-case word w
+func (a *Analyzer) euphonicForm(r *euphonyRule, w string) string {
+	first, size := utf8.DecodeRuneInString(w)
+	if !unicode.IsLetter(first) {
+		return r.short
+	}
+	if r.words[w] {
+		return r.long
+	}
+	lemmas := a.lemmas(w)
+	for _, l := range lemmas {
+		if r.lemmas[l] {
+			return r.long
+		}
+	}
+	// "об" goes before a vowel sound only: "об окне" but "о ёлке", "о юге"
+	if strings.ContainsRune(vowelSounds, first) {
+		return r.vowel
+	}
+
+	if !startsWithCluster(w) {
+		return r.short
+	}
+	if strings.ContainsRune(r.clusters, first) {
+		return r.long
+	}
+	if r.fleeting {
+		for _, l := range lemmas {
+			lf, lsize := utf8.DecodeRuneInString(l)
+			second, _ := utf8.DecodeRuneInString(l[lsize:])
+			if lf == first && isVowel(second) && l[lsize:] != w[size:] {
+				return r.long
+			}
+		}
+	}
+	return r.short
+}
+
+// lemmas returns the dictionary forms of every analysis of word
+func (a *Analyzer) lemmas(word string) []string {
+	var out []string
+	for _, p := range a.parses(word) {
+		if forms, ok := a.lexemeForms(word, p.entry); ok && len(forms) > 0 {
+			out = appendUnique(out, forms[0].text)
+		}
+	}
+	return out
+}
+
+// applyEuphony replaces every preposition in words with the form suited to
+// the word after it
+func (a *Analyzer) applyEuphony(words []string) {
+	for i := 0; i+1 < len(words); i++ {
+		if _, ok := euphonyRules[words[i]]; ok {
+			words[i] = a.PrepositionForm(words[i], words[i+1])
+		}
+	}
+}
+
+// vowelSounds are the vowel letters read without a leading [j]
+const vowelSounds = "аиоуыэ"
+
+// isVowel reports whether r is a Russian vowel letter
+func isVowel(r rune) bool {
+	return strings.ContainsRune("аеёиоуыэюя", r)
+}
+
+// startsWithCluster reports whether w starts with two consonants, looking
+// past a soft or hard sign between them ("стол", "льда")
+func startsWithCluster(w string) bool {
+	var letters []rune
+	for _, r := range w {
+		if r == 'ь' || r == 'ъ' {
+			continue
+		}
+		letters = append(letters, r)
+		if len(letters) == 2 {
+			break
+		}
+	}
+	return len(letters) == 2 && !isVowel(letters[0]) && !isVowel(letters[1]) &&
+		unicode.IsLetter(letters[0]) && unicode.IsLetter(letters[1]) && letters[0] != 'й'
+}
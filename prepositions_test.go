@@ -0,0 +1,69 @@
+package gomorphy
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestPrepositionCases(t *testing.T) {
+	tests := []struct {
+		prep string
+		want []string
+	}{
+		{"к", []string{"datv"}},
+		{"в", []string{"accs", "loct", "loc2"}},
+		{"С", []string{"gent", "accs", "ablt"}},
+		{"кошка", nil},
+	}
+	for _, tt := range tests {
+		if got := PrepositionCases(tt.prep); !slices.Equal(got, tt.want) {
+			t.Errorf("PrepositionCases(%q) = %v, want %v", tt.prep, got, tt.want)
+		}
+	}
+
+	// The returned slice must not alias the government table
+	PrepositionCases("к")[0] = "nomn"
+	if got := PrepositionCases("к"); got[0] != "datv" {
+		t.Errorf("PrepositionCases(к) modified through returned slice: %v", got)
+	}
+}
+
+func TestPrepositionForm(t *testing.T) {
+	a := testAnalyzer
+	tests := []struct {
+		prep, next string
+		want       string
+	}{
+		{"о", "окне", "об"},
+		{"о", "ёлке", "о"},
+		{"о", "юге", "о"},
+		{"о", "Европе", "о"},
+		{"о", "истории", "об"},
+		{"о", "мне", "обо"},
+		{"о", "всём", "обо"},
+		{"об", "доме", "о"},
+		{"с", "стола", "со"},
+		{"с", "другом", "с"},
+		{"с", "сестрой", "с"},
+		{"с", "мной", "со"},
+		{"с", "льда", "со"},
+		{"в", "время", "во"},
+		{"в", "вторник", "во"},
+		{"в", "Франции", "во"},
+		{"в", "доме", "в"},
+		{"в", "рту", "во"},
+		{"во", "саду", "в"},
+		{"к", "мне", "ко"},
+		{"к", "дому", "к"},
+		{"к", "всем", "ко"},
+		{"из", "рта", "изо"},
+		{"под", "мной", "подо"},
+		{"В", "время", "Во"},
+		{"на", "столе", "на"},
+	}
+	for _, tt := range tests {
+		if got := a.PrepositionForm(tt.prep, tt.next); got != tt.want {
+			t.Errorf("PrepositionForm(%q, %q) = %q, want %q", tt.prep, tt.next, got, tt.want)
+		}
+	}
+}
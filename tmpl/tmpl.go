@@ -0,0 +1,165 @@
+// Package tmpl provides template functions for grammatical agreement in
+// Russian text, backed by the gomorphy analyzer:
+//
+//	t := template.New("notice").Funcs(tmpl.FuncMap(a))
+//	// {{plural .Count "новое сообщение"}} → "5 новых сообщений"
+//	// {{case "datv" .UserName}}           → "Ивану Петрову"
+//	// {{gender .UserName "вошёл" "вошла"}} → "вошла"
+//
+// The map works with html/template as well after a conversion to its own
+// FuncMap type:
+//
+//	t := htmltemplate.New("notice").Funcs(htmltemplate.FuncMap(tmpl.FuncMap(a)))
+//
+// A word that cannot be inflected stops the execution with an error instead
+// of being left in its original form
+package tmpl
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"text/template"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/jus1d/gomorphy"
+)
+
+// FuncMap returns the template functions backed by a:
+//
+//	plural N PHRASE [CASE]  N followed by PHRASE agreeing with it: "5 новых сообщений"
+//	case CASE PHRASE        PHRASE declined to CASE in its own number; a
+//	                        PHRASE the dictionary reads as a personal name
+//	                        is declined as one
+//	name CASE NAME [GENDER] NAME declined as a personal name
+//	gender WHO MASC FEMN    MASC or FEMN by the gender of WHO, which is a
+//	                        gender grammeme ("masc", "femn") or a personal name
+func FuncMap(a *gomorphy.Analyzer) template.FuncMap {
+	return template.FuncMap{
+		"plural": func(n any, phrase string, cas ...string) (string, error) {
+			return plural(a, n, phrase, cas...)
+		},
+		"case": func(cas, phrase string) (string, error) {
+			return inflect(a, cas, phrase)
+		},
+		"name": func(cas, name string, gender ...string) (string, error) {
+			return inflectName(a, cas, name, gender...)
+		},
+		"gender": func(who, masc, femn string) (string, error) {
+			return selectGender(a, who, masc, femn)
+		},
+	}
+}
+
+// plural writes n followed by phrase agreeing with it in cas, nominative by default
+func plural(a *gomorphy.Analyzer, n any, phrase string, cas ...string) (string, error) {
+	count, err := toInt64(n)
+	if err != nil {
+		return "", err
+	}
+	c, err := optionalCase(cas)
+	if err != nil {
+		return "", err
+	}
+	s, ok := a.CountPhrase(count, phrase, c)
+	if !ok {
+		return "", fmt.Errorf("plural: cannot agree %q with %d", phrase, count)
+	}
+	return strconv.FormatInt(count, 10) + " " + s, nil
+}
+
+// inflect declines phrase to cas keeping its number and leading capital;
+// phrases made of Name, Patr and Surn words are taken for personal names
+func inflect(a *gomorphy.Analyzer, cas, phrase string) (string, error) {
+	if a.IsPersonalName(phrase) {
+		return inflectName(a, cas, phrase)
+	}
+	number := "sing"
+	if readings := a.PhraseReadings(phrase); len(readings) > 0 && !hasNumber(readings, "sing") {
+		number = "plur"
+	}
+	s, ok := a.InflectPhrase(phrase, cas, number)
+	if !ok {
+		return "", fmt.Errorf("case: cannot inflect %q to %s", phrase, cas)
+	}
+	if startsUpper(phrase) {
+		r, size := utf8.DecodeRuneInString(s)
+		s = string(unicode.ToUpper(r)) + s[size:]
+	}
+	return s, nil
+}
+
+// inflectName declines a personal name to cas with an optional gender
+func inflectName(a *gomorphy.Analyzer, cas, name string, gender ...string) (string, error) {
+	if len(gender) > 1 {
+		return "", fmt.Errorf("name: expected at most one gender, got %d", len(gender))
+	}
+	g := ""
+	if len(gender) == 1 {
+		g = gender[0]
+	}
+	s, ok := a.InflectName(name, cas, g)
+	if !ok {
+		return "", fmt.Errorf("name: cannot inflect %q to %s", name, cas)
+	}
+	return s, nil
+}
+
+// selectGender returns masc or femn by the gender of who
+func selectGender(a *gomorphy.Analyzer, who, masc, femn string) (string, error) {
+	g := who
+	if g != "masc" && g != "femn" {
+		g, _ = a.GuessGender(who)
+	}
+	switch g {
+	case "masc":
+		return masc, nil
+	case "femn":
+		return femn, nil
+	}
+	return "", fmt.Errorf("gender: cannot tell the gender of %q", who)
+}
+
+// optionalCase returns the single optional case argument, nomn when absent
+func optionalCase(cas []string) (string, error) {
+	switch len(cas) {
+	case 0:
+		return "nomn", nil
+	case 1:
+		return cas[0], nil
+	}
+	return "", fmt.Errorf("expected at most one case, got %d", len(cas))
+}
+
+// toInt64 converts a template number argument of any integer kind
+func toInt64(n any) (int64, error) {
+	v := reflect.ValueOf(n)
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int(), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return int64(v.Uint()), nil
+	}
+	return 0, fmt.Errorf("plural: expected an integer, got %T", n)
+}
+
+// hasNumber reports whether any reading is in number
+func hasNumber(readings []gomorphy.Cell, number string) bool {
+	for _, r := range readings {
+		if r.Number == number {
+			return true
+		}
+	}
+	return false
+}
+
+// startsUpper reports whether s starts with an upper-case letter
+func startsUpper(s string) bool {
+	s = strings.TrimSpace(s)
+	for _, r := range s {
+		return unicode.IsUpper(r)
+	}
+	return false
+}
@@ -0,0 +1,87 @@
+package tmpl
+
+import (
+	htmltemplate "html/template"
+	"strings"
+	"testing"
+	"text/template"
+
+	"github.com/jus1d/gomorphy"
+)
+
+// shared function map reused across all tests
+var testFuncs = func() template.FuncMap {
+	a, err := gomorphy.Default()
+	if err != nil {
+		panic("failed to load analyzer: " + err.Error())
+	}
+	return FuncMap(a)
+}()
+
+func execute(t *testing.T, text string, data any) (string, error) {
+	t.Helper()
+	tpl, err := template.New("test").Funcs(testFuncs).Parse(text)
+	if err != nil {
+		t.Fatalf("Parse(%q) error: %v", text, err)
+	}
+	var b strings.Builder
+	err = tpl.Execute(&b, data)
+	return b.String(), err
+}
+
+func TestFuncMap(t *testing.T) {
+	data := map[string]any{"Count": 5, "One": 1, "UserName": "Мария Иванова", "Man": "Иван Петров"}
+
+	tests := []struct {
+		text string
+		want string
+	}{
+		{`{{plural .Count "новое сообщение"}}`, "5 новых сообщений"},
+		{`{{plural .One "новое сообщение"}}`, "1 новое сообщение"},
+		{`{{plural 3 "новое сообщение" "gent"}}`, "3 новых сообщений"},
+		{`{{plural 5 "год"}}`, "5 лет"},
+		{`{{case "datv" "красивая кошка"}}`, "красивой кошке"},
+		{`{{case "gent" "острые ножницы"}}`, "острых ножниц"},
+		{`{{case "datv" .Man}}`, "Ивану Петрову"},
+		{`{{case "datv" "Новое сообщение"}}`, "Новому сообщению"},
+		{`{{name "gent" .UserName}}`, "Марии Ивановой"},
+		{`{{name "datv" "Саша" "femn"}}`, "Саше"},
+		{`{{gender .UserName "вошёл" "вошла"}}`, "вошла"},
+		{`{{gender .Man "вошёл" "вошла"}}`, "вошёл"},
+		{`{{gender "femn" "вошёл" "вошла"}}`, "вошла"},
+	}
+
+	for _, tt := range tests {
+		got, err := execute(t, tt.text, data)
+		if err != nil || got != tt.want {
+			t.Errorf("%s = %q, %v; want %q", tt.text, got, err, tt.want)
+		}
+	}
+}
+
+func TestFuncMap_Errors(t *testing.T) {
+	tests := []string{
+		`{{plural "пять" "сообщение"}}`,
+		`{{plural 5 "быстро"}}`,
+		`{{case "datv" "быстро"}}`,
+		`{{case "nominative" "кошка"}}`,
+		`{{case "" "кошка"}}`,
+		`{{case "datv" "Быстро"}}`,
+		`{{gender "Саша" "вошёл" "вошла"}}`,
+		`{{name "gent" ""}}`,
+	}
+
+	for _, text := range tests {
+		if got, err := execute(t, text, nil); err == nil {
+			t.Errorf("%s = %q, want an execution error", text, got)
+		}
+	}
+}
+
+func TestFuncMap_HTML(t *testing.T) {
+	tpl := htmltemplate.Must(htmltemplate.New("test").Funcs(htmltemplate.FuncMap(testFuncs)).Parse(`<b>{{plural . "файл"}}</b>`))
+	var b strings.Builder
+	if err := tpl.Execute(&b, 2); err != nil || b.String() != "<b>2 файла</b>" {
+		t.Errorf("html template = %q, %v; want %q", b.String(), err, "<b>2 файла</b>")
+	}
+}
@@ -0,0 +1,288 @@
+package gomorphy
+
+import "strings"
+
+// nameGrammemes mark the parts of a personal name
+var nameGrammemes = []string{"Name", "Surn", "Patr"}
+
+// AgreePredicate puts a verb, short adjective or participle given in any
+// form into the form agreeing with a subject of the given gender and number:
+// the past tense for verbs, the short form for adjectives and participles,
+// e.g. AgreePredicate("войти", "femn", "sing") → "вошла",
+// AgreePredicate("готовый", "neut", "sing") → "готово" and
+// AgreePredicate("прочитанный", "", "plur") → "прочитаны"
+//
+// gender is ignored in the plural
+// Reports false if the word has no such forms or gender is missing in the
+// singular
+func (a *Analyzer) AgreePredicate(word, gender, number string) (string, bool) {
+	word = strings.ToLower(strings.TrimSpace(word))
+	if number == "sing" && gender == "" || number != "sing" && number != "plur" {
+		return "", false
+	}
+	if number == "plur" {
+		gender = ""
+	}
+	for _, p := range a.parses(word) {
+		target, marks := predicatePOS(p.tag)
+		if target == "" {
+			continue
+		}
+		forms, ok := a.lexemeForms(word, p.entry)
+		if !ok {
+			continue
+		}
+		for _, f := range forms {
+			if tagPOS(f.tag) == target && hasGrammemes(f.tag, marks) && tagMatches(f.tag, "", number, gender, "") {
+				return f.text, true
+			}
+		}
+	}
+	return "", false
+}
+
+// AgreePredicateWith agrees a predicate with a subject noun phrase, see
+// [Analyzer.AgreePredicate], e.g.
+// AgreePredicateWith("войти", "пользователь Анна") → "вошла" and
+// AgreePredicateWith("войти", "Анна и Иван") → "вошли"
+//
+// The gender of a personal name in the subject wins over the gender of the
+// noun it stands in apposition to, so the verb agrees with the person.
+// Subjects joined by "и" and plural heads take the plural
+// Reports false if the subject has no noun or its gender cannot be told
+func (a *Analyzer) AgreePredicateWith(word, subject string) (string, bool) {
+	gender, number, ok := a.subjectAgreement(subject)
+	if !ok {
+		return "", false
+	}
+	return a.AgreePredicate(word, gender, number)
+}
+
+// subjectAgreement returns the gender and number a predicate takes after
+// subject
+func (a *Analyzer) subjectAgreement(subject string) (string, string, bool) {
+	words := strings.Fields(strings.ToLower(strings.TrimSpace(subject)))
+	if len(words) == 0 {
+		return "", "", false
+	}
+	for _, w := range words {
+		if w == "и" {
+			return "", "plur", true
+		}
+	}
+	p := a.analyzePhrase(words)
+	if p.head == -1 {
+		return "", "", false
+	}
+	head := p.words[p.head]
+	if tagGrammeme(head.parse.tag, numberGrammemes) == "plur" {
+		return "", "plur", true
+	}
+
+	var names []string
+	for _, w := range p.words {
+		if isNominal(w.pos) && tagGrammeme(w.parse.tag, nameGrammemes) != "" {
+			names = append(names, w.text)
+		}
+	}
+	if len(names) > 0 {
+		if g, _ := a.GuessGender(strings.Join(names, " ")); g != "" {
+			return g, "sing", true
+		}
+	}
+	if head.gender == "" {
+		return "", "", false
+	}
+	return head.gender, "sing", true
+}
+
+// predicatePOS returns the part of speech and the grammemes of the
+// predicate form agreeing with a subject for a word with tag: the past of
+// a verb, the short form of an adjective, the short participle of the same
+// tense and voice. The POS is empty for other words
+func predicatePOS(tag string) (string, []string) {
+	switch tagPOS(tag) {
+	case "VERB", "INFN", "GRND":
+		return "VERB", []string{"past"}
+	case "ADJF", "ADJS":
+		return "ADJS", nil
+	case "PRTF", "PRTS":
+		return "PRTS", []string{tagGrammeme(tag, []string{"pres", "past"}), tagGrammeme(tag, []string{"actv", "pssv"})}
+	}
+	return "", nil
+}
+
+// hasGrammemes reports whether tag contains every grammeme of marks
+func hasGrammemes(tag string, marks []string) bool {
+	for _, g := range marks {
+		if !strings.Contains(tag, g) {
+			return false
+		}
+	}
+	return true
+}
+
+// analyticFuture are the forms of "быть" that build the future of
+// imperfective verbs, which their paradigms do not contain
+var analyticFuture = map[PersonNumber]string{
+	{"1per", "sing"}: "буду", {"2per", "sing"}: "будешь", {"3per", "sing"}: "будет",
+	{"1per", "plur"}: "будем", {"2per", "plur"}: "будете", {"3per", "plur"}: "будут",
+}
+
+// PersonNumber keys personal verb forms: person is "1per", "2per" or "3per",
+// number is "sing" or "plur"
+type PersonNumber struct {
+	Person string
+	Number string
+}
+
+// Form is a word form with its OpenCorpora tag
+type Form struct {
+	Text string
+	Tag  string
+}
+
+// Participle is one participle of a verb, declined like an adjective
+type Participle struct {
+	Tense string // "pres" or "past"
+	Voice string // "actv" or "pssv"
+	Forms []Form // full forms in paradigm order, "читающий", "читающего", ...
+	// Short holds the short forms of passive participles keyed by gender in
+	// the singular and by "plur" in the plural: "прочитан", "прочитана", ...
+	Short map[string]string
+}
+
+// Decline returns the full form of the participle agreeing with a noun of
+// the given case, number, gender and animacy
+// Reports false if the participle has no such form
+func (p *Participle) Decline(cas, number, gender, animacy string) (string, bool) {
+	c, g := adjAgreement(cas, number, gender, animacy)
+	for _, f := range p.Forms {
+		if tagMatches(f.Tag, c, number, g, "") {
+			return f.Text, true
+		}
+	}
+	return "", false
+}
+
+// Conjugation is the full table of a verb's forms
+// Forms missing from the paradigm are absent from their maps
+type Conjugation struct {
+	Infinitive   string
+	Aspect       string // "perf" or "impf"
+	Transitivity string // "tran" or "intr"
+
+	// Present holds the present tense of imperfective verbs
+	Present map[PersonNumber]string
+	// Future holds the simple future of perfective verbs and the compound
+	// future of imperfective ones ("буду читать")
+	Future map[PersonNumber]string
+	// Past is keyed by gender in the singular and by "plur" in the plural
+	Past map[string]string
+	// Imperative is keyed by number: "читай", "читайте"
+	Imperative map[string]string
+	// Inclusive holds the joint imperative keyed by number: "пойдём", "пойдёмте"
+	Inclusive map[string]string
+	// Gerunds are keyed by tense: "читая", "читав"
+	Gerunds map[string]string
+	// Participles are ordered as in the paradigm, one per tense and voice
+	Participles []*Participle
+}
+
+// Conjugate returns the conjugation table of a verb given in any form,
+// including its participles and gerunds, e.g. Conjugate("читала")
+// Reports false if the word is not a verb form in the dictionary
+func (a *Analyzer) Conjugate(verb string) (*Conjugation, bool) {
+	verb = strings.ToLower(strings.TrimSpace(verb))
+	for _, p := range a.parses(verb) {
+		switch tagPOS(p.tag) {
+		case "VERB", "INFN", "GRND", "PRTF", "PRTS":
+		default:
+			continue
+		}
+		forms, ok := a.lexemeForms(verb, p.entry)
+		if !ok {
+			continue
+		}
+		return conjugation(forms), true
+	}
+	return nil, false
+}
+
+// conjugation sorts the forms of a verb lexeme into a table; where the
+// paradigm has variants of a form, the first one is kept
+func conjugation(forms []lexemeForm) *Conjugation {
+	c := &Conjugation{
+		Present:    make(map[PersonNumber]string),
+		Future:     make(map[PersonNumber]string),
+		Past:       make(map[string]string),
+		Imperative: make(map[string]string),
+		Inclusive:  make(map[string]string),
+		Gerunds:    make(map[string]string),
+	}
+	setOnce := func(m map[string]string, key, form string) {
+		if _, ok := m[key]; !ok {
+			m[key] = form
+		}
+	}
+	participles := make(map[string]*Participle)
+	participle := func(tag string) *Participle {
+		tense := tagGrammeme(tag, []string{"pres", "past"})
+		voice := tagGrammeme(tag, []string{"actv", "pssv"})
+		pt, ok := participles[tense+voice]
+		if !ok {
+			pt = &Participle{Tense: tense, Voice: voice, Short: make(map[string]string)}
+			participles[tense+voice] = pt
+			c.Participles = append(c.Participles, pt)
+		}
+		return pt
+	}
+
+	for _, f := range forms {
+		number := tagGrammeme(f.tag, numberGrammemes)
+		agreement := number
+		if g := tagGrammeme(f.tag, genderGrammemes); number == "sing" && g != "" {
+			agreement = g
+		}
+		switch tagPOS(f.tag) {
+		case "INFN":
+			if c.Infinitive == "" {
+				c.Infinitive = f.text
+				c.Aspect = tagGrammeme(f.tag, []string{"perf", "impf"})
+				c.Transitivity = tagGrammeme(f.tag, []string{"tran", "intr"})
+			}
+		case "VERB":
+			key := PersonNumber{tagGrammeme(f.tag, []string{"1per", "2per", "3per"}), number}
+			switch {
+			case strings.Contains(f.tag, "pres"):
+				if _, ok := c.Present[key]; !ok {
+					c.Present[key] = f.text
+				}
+			case strings.Contains(f.tag, "futr"):
+				if _, ok := c.Future[key]; !ok {
+					c.Future[key] = f.text
+				}
+			case strings.Contains(f.tag, "past"):
+				setOnce(c.Past, agreement, f.text)
+			case strings.Contains(f.tag, "incl"):
+				setOnce(c.Inclusive, number, f.text)
+			case strings.Contains(f.tag, "impr"):
+				setOnce(c.Imperative, number, f.text)
+			}
+		case "GRND":
+			setOnce(c.Gerunds, tagGrammeme(f.tag, []string{"pres", "past"}), f.text)
+		case "PRTF":
+			pt := participle(f.tag)
+			pt.Forms = append(pt.Forms, Form{Text: f.text, Tag: f.tag})
+		case "PRTS":
+			setOnce(participle(f.tag).Short, agreement, f.text)
+		}
+	}
+
+	if c.Aspect == "impf" && len(c.Future) == 0 && c.Infinitive != "" {
+		for key, aux := range analyticFuture {
+			c.Future[key] = aux + " " + c.Infinitive
+		}
+	}
+	return c
+}
@@ -0,0 +1,142 @@
+package gomorphy
+
+import "testing"
+
+func TestAgreePredicate(t *testing.T) {
+	a := testAnalyzer
+
+	tests := []struct {
+		word, gender, number string
+		want                 string
+	}{
+		{"войти", "femn", "sing", "вошла"},
+		{"войти", "masc", "sing", "вошёл"},
+		{"вошёл", "neut", "sing", "вошло"},
+		{"войти", "", "plur", "вошли"},
+		{"читает", "femn", "sing", "читала"},
+		{"готовый", "neut", "sing", "готово"},
+		{"готова", "masc", "sing", "готов"},
+		{"готовый", "femn", "plur", "готовы"},
+		{"прочитанный", "femn", "sing", "прочитана"},
+		{"прочитанный", "", "plur", "прочитаны"},
+	}
+
+	for _, tt := range tests {
+		got, ok := a.AgreePredicate(tt.word, tt.gender, tt.number)
+		if !ok || got != tt.want {
+			t.Errorf("AgreePredicate(%q, %q, %q) = %q, %v; want %q", tt.word, tt.gender, tt.number, got, ok, tt.want)
+		}
+	}
+
+	for _, tt := range []struct{ word, gender, number string }{
+		{"войти", "", "sing"},
+		{"кошка", "femn", "sing"},
+		{"войти", "femn", "dual"},
+	} {
+		if got, ok := a.AgreePredicate(tt.word, tt.gender, tt.number); ok {
+			t.Errorf("AgreePredicate(%q, %q, %q) = %q, want failure", tt.word, tt.gender, tt.number, got)
+		}
+	}
+}
+
+func TestAgreePredicateWith(t *testing.T) {
+	a := testAnalyzer
+
+	tests := []struct {
+		word, subject string
+		want          string
+	}{
+		{"войти", "пользователь Анна", "вошла"},
+		{"войти", "пользователь", "вошёл"},
+		{"войти", "Анна и Иван", "вошли"},
+		{"войти", "новые пользователи", "вошли"},
+		{"закрыть", "дверь", "закрыла"},
+		{"готовый", "письмо", "готово"},
+		{"сломанный", "ножницы", "сломаны"},
+	}
+
+	for _, tt := range tests {
+		got, ok := a.AgreePredicateWith(tt.word, tt.subject)
+		if !ok || got != tt.want {
+			t.Errorf("AgreePredicateWith(%q, %q) = %q, %v; want %q", tt.word, tt.subject, got, ok, tt.want)
+		}
+	}
+}
+
+func TestConjugate(t *testing.T) {
+	a := testAnalyzer
+
+	c, ok := a.Conjugate("читала")
+	if !ok {
+		t.Fatal("Conjugate(\"читала\") failed")
+	}
+	if c.Infinitive != "читать" || c.Aspect != "impf" || c.Transitivity != "tran" {
+		t.Errorf("Conjugate(\"читала\") = %q %s %s, want читать impf tran", c.Infinitive, c.Aspect, c.Transitivity)
+	}
+
+	checks := []struct {
+		name string
+		got  string
+		want string
+	}{
+		{"Present 1 sing", c.Present[PersonNumber{"1per", "sing"}], "читаю"},
+		{"Present 2 sing", c.Present[PersonNumber{"2per", "sing"}], "читаешь"},
+		{"Present 3 plur", c.Present[PersonNumber{"3per", "plur"}], "читают"},
+		{"Future 1 plur", c.Future[PersonNumber{"1per", "plur"}], "будем читать"},
+		{"Past masc", c.Past["masc"], "читал"},
+		{"Past femn", c.Past["femn"], "читала"},
+		{"Past plur", c.Past["plur"], "читали"},
+		{"Imperative sing", c.Imperative["sing"], "читай"},
+		{"Imperative plur", c.Imperative["plur"], "читайте"},
+		{"Gerund pres", c.Gerunds["pres"], "читая"},
+	}
+	for _, ch := range checks {
+		if ch.got != ch.want {
+			t.Errorf("%s = %q, want %q", ch.name, ch.got, ch.want)
+		}
+	}
+
+	var active *Participle
+	for _, p := range c.Participles {
+		if p.Tense == "pres" && p.Voice == "actv" {
+			active = p
+		}
+	}
+	if active == nil {
+		t.Fatal("Conjugate(\"читать\") has no present active participle")
+	}
+	if got, _ := active.Decline("datv", "sing", "femn", ""); got != "читающей" {
+		t.Errorf("participle datv femn = %q, want %q", got, "читающей")
+	}
+	if got, _ := active.Decline("accs", "sing", "masc", "anim"); got != "читающего" {
+		t.Errorf("participle accs masc anim = %q, want %q", got, "читающего")
+	}
+}
+
+func TestConjugate_Perfective(t *testing.T) {
+	a := testAnalyzer
+
+	c, ok := a.Conjugate("прочитать")
+	if !ok {
+		t.Fatal("Conjugate(\"прочитать\") failed")
+	}
+	if c.Aspect != "perf" || len(c.Present) != 0 {
+		t.Errorf("perfective verb: aspect %q, %d present forms", c.Aspect, len(c.Present))
+	}
+	if got := c.Future[PersonNumber{"3per", "sing"}]; got != "прочитает" {
+		t.Errorf("Future 3 sing = %q, want %q", got, "прочитает")
+	}
+	for _, p := range c.Participles {
+		if p.Tense == "past" && p.Voice == "pssv" && p.Short["femn"] != "прочитана" {
+			t.Errorf("short participle femn = %q, want %q", p.Short["femn"], "прочитана")
+		}
+	}
+
+	if c, ok := a.Conjugate("пойти"); !ok || c.Inclusive["sing"] != "пойдём" {
+		t.Errorf("Conjugate(\"пойти\").Inclusive = %v, %v; want пойдём", c, ok)
+	}
+
+	if _, ok := a.Conjugate("кошка"); ok {
+		t.Error("Conjugate of a noun should fail")
+	}
+}